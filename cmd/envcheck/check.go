@@ -0,0 +1,92 @@
+package main
+
+import (
+	"gitlab.com/gridwhizth/universe/env"
+	"sort"
+	"strings"
+)
+
+// Report compares the envs used in code with the ones a deployment provides.
+type Report struct {
+	Missing         []Usage
+	MissingOptional []Usage
+	Unused          []string
+	Deprecated      []Rename
+}
+
+// Rename is a former name of an env, declared with env.Alias, the
+// deployment still provides.
+type Rename struct {
+	Old string
+	New string
+}
+
+func (r Report) Failed(strict bool) bool {
+	return len(r.Missing) > 0 || (strict && len(r.Unused) > 0)
+}
+
+// check reports the required and optional envs the deployment does not
+// provide, an env provided through its _FILE variant counts as provided,
+// and the provided envs no code uses. An env of env.RequireIn is required
+// when the deployed profile is one of its profiles. An env provided under a
+// former name listed in aliases is provided and reported as deprecated.
+func check(usages []Usage, aliases map[string][]string, provided []string, deployedProfile string) Report {
+	providedSet := map[string]bool{}
+	for _, name := range provided {
+		providedSet[name] = true
+	}
+
+	var report Report
+	used := map[string]bool{}
+	reported := map[string]bool{}
+	renamed := map[string]bool{}
+	for _, usage := range usages {
+		used[usage.Name] = true
+		used[usage.Name+env.FileSuffix] = true
+		providedAsAlias := false
+		for _, old := range aliases[usage.Name] {
+			used[old] = true
+			used[old+env.FileSuffix] = true
+			if providedSet[old] || providedSet[old+env.FileSuffix] {
+				providedAsAlias = true
+				if !renamed[old] {
+					renamed[old] = true
+					report.Deprecated = append(report.Deprecated, Rename{Old: old, New: usage.Name})
+				}
+			}
+		}
+		if providedSet[usage.Name] || providedSet[usage.Name+env.FileSuffix] || providedAsAlias || reported[usage.Name+usage.Requirement] {
+			continue
+		}
+		reported[usage.Name+usage.Requirement] = true
+		usage.Requirement = requirementIn(usage, deployedProfile)
+		if usage.Requirement == required {
+			report.Missing = append(report.Missing, usage)
+		} else {
+			report.MissingOptional = append(report.MissingOptional, usage)
+		}
+	}
+
+	for _, name := range provided {
+		if !used[name] && !strings.HasPrefix(name, "$") {
+			report.Unused = append(report.Unused, name)
+		}
+	}
+	sort.Strings(report.Unused)
+	return report
+}
+
+// requirementIn resolves the requirement of a usage in the deployed profile,
+// an env.RequireIn usage stays required in profile when the profile or its
+// profiles are unknown.
+func requirementIn(usage Usage, deployedProfile string) string {
+	if usage.Requirement != profile || deployedProfile == "" || usage.Profiles == nil {
+		return usage.Requirement
+	}
+	for _, p := range usage.Profiles {
+		if strings.EqualFold(p, deployedProfile) {
+			return required
+		}
+	}
+	return optional
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	usages := []Usage{
+		{Name: "DATABASE_URL", Requirement: required},
+		{Name: "API_KEY", Requirement: required},
+		{Name: "PORT", Requirement: required},
+		{Name: "PORT", Requirement: required},
+		{Name: "SENTRY_DSN", Requirement: optional},
+		{Name: "TOKEN", Requirement: profile},
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		report := check(usages, nil, []string{"DATABASE_URL", "API_KEY_FILE", "PORT", "SENTRY_DSN", "TOKEN"}, "")
+		assert.Empty(t, report.Missing)
+		assert.Empty(t, report.MissingOptional)
+		assert.Empty(t, report.Unused)
+		assert.False(t, report.Failed(true))
+	})
+
+	t.Run("Unhappy, missing and unused", func(t *testing.T) {
+		report := check(usages, nil, []string{"DATABASE_URL", "LEGACY"}, "")
+		assert.Equal(t, []Usage{usages[1], usages[2]}, report.Missing)
+		assert.Equal(t, []Usage{usages[4], usages[5]}, report.MissingOptional)
+		assert.Equal(t, []string{"LEGACY"}, report.Unused)
+		assert.True(t, report.Failed(false))
+	})
+
+	t.Run("Unhappy, unused in strict mode", func(t *testing.T) {
+		report := check(usages[:1], nil, []string{"DATABASE_URL", "LEGACY"}, "")
+		assert.False(t, report.Failed(false))
+		assert.True(t, report.Failed(true))
+	})
+	t.Run("Unhappy, required in the deployed profile", func(t *testing.T) {
+		profiled := []Usage{
+			{Name: "SENTRY_DSN", Requirement: profile, Profiles: []string{"staging", "production"}},
+			{Name: "TRACING_URL", Requirement: profile, Profiles: []string{"staging"}},
+			{Name: "AUDIT_URL", Requirement: profile},
+		}
+
+		report := check(profiled, nil, nil, "Production")
+		assert.Len(t, report.Missing, 1)
+		assert.Equal(t, "SENTRY_DSN", report.Missing[0].Name)
+		assert.Equal(t, required, report.Missing[0].Requirement)
+		assert.Len(t, report.MissingOptional, 2)
+		assert.Equal(t, optional, report.MissingOptional[0].Requirement)
+		assert.Equal(t, profile, report.MissingOptional[1].Requirement)
+		assert.True(t, report.Failed(false))
+
+		report = check(profiled, nil, nil, "")
+		assert.Empty(t, report.Missing)
+		assert.Len(t, report.MissingOptional, 3)
+	})
+	t.Run("Happy, provided under a former name", func(t *testing.T) {
+		aliases := map[string][]string{"DATABASE_URL": {"DB_URL", "DATABASE"}}
+		report := check(usages[:1], aliases, []string{"DB_URL_FILE"}, "")
+		assert.Empty(t, report.Missing)
+		assert.Empty(t, report.Unused)
+		assert.Equal(t, []Rename{{Old: "DB_URL", New: "DATABASE_URL"}}, report.Deprecated)
+		assert.False(t, report.Failed(true))
+	})
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"gitlab.com/gridwhizth/universe/env"
+	"gopkg.in/yaml.v2"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	formatAuto   = "auto"
+	formatDotenv = "dotenv"
+	formatYAML   = "yaml"
+)
+
+// readDeployment returns the env names provided by a dotenv file, or by a
+// Kubernetes manifest or Helm values file for the yaml format.
+func readDeployment(path string, format string) ([]string, error) {
+	if format == formatAuto {
+		format = detectFormat(path)
+	}
+
+	switch format {
+	case formatDotenv:
+		values, err := env.ReadDotenv(path)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, nil
+	case formatYAML:
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		names, err := yamlEnvNames(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return names, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func detectFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return formatYAML
+	}
+	return formatDotenv
+}
+
+// yamlEnvNames collects the names of every `env` list of name/value pairs
+// or `env` mapping, as found in container specs and Helm values, and the
+// keys of the ConfigMaps and Secrets of a manifest.
+func yamlEnvNames(content string) ([]string, error) {
+	seen := map[string]bool{}
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(content)))
+	for {
+		var document interface{}
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		collectEnvNames(document, seen)
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func collectEnvNames(node interface{}, seen map[string]bool) {
+	switch value := node.(type) {
+	case map[interface{}]interface{}:
+		kind, _ := value["kind"].(string)
+		for key, child := range value {
+			switch {
+			case key == "env":
+				collectEnvEntries(child, seen)
+			case (kind == "ConfigMap" || kind == "Secret") && (key == "data" || key == "stringData"):
+				collectKeys(child, seen)
+			}
+			collectEnvNames(child, seen)
+		}
+	case []interface{}:
+		for _, child := range value {
+			collectEnvNames(child, seen)
+		}
+	}
+}
+
+func collectEnvEntries(node interface{}, seen map[string]bool) {
+	switch value := node.(type) {
+	case []interface{}:
+		for _, entry := range value {
+			if entry, ok := entry.(map[interface{}]interface{}); ok {
+				if name, ok := entry["name"].(string); ok {
+					seen[name] = true
+				}
+			}
+		}
+	case map[interface{}]interface{}:
+		collectKeys(value, seen)
+	}
+}
+
+func collectKeys(node interface{}, seen map[string]bool) {
+	if value, ok := node.(map[interface{}]interface{}); ok {
+		for key := range value {
+			if name, ok := key.(string); ok {
+				seen[name] = true
+			}
+		}
+	}
+}
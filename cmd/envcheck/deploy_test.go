@@ -0,0 +1,92 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestReadDeployment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "envcheck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Run("Happy, dotenv", func(t *testing.T) {
+		path := writeFile(t, dir, ".env.production", "PORT=80\nexport HOST=example.com\n")
+		names, err := readDeployment(path, formatAuto)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"HOST", "PORT"}, names)
+	})
+
+	t.Run("Happy, kubernetes manifest", func(t *testing.T) {
+		path := writeFile(t, dir, "deployment.yaml", `apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          env:
+            - name: PORT
+              value: "80"
+            - name: DATABASE_URL
+              valueFrom:
+                secretKeyRef:
+                  name: app
+                  key: url
+---
+apiVersion: v1
+kind: ConfigMap
+data:
+  HOST: example.com
+---
+apiVersion: v1
+kind: Secret
+stringData:
+  API_KEY_FILE: /run/secrets/api-key
+`)
+		names, err := readDeployment(path, formatAuto)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"API_KEY_FILE", "DATABASE_URL", "HOST", "PORT"}, names)
+	})
+
+	t.Run("Happy, helm values", func(t *testing.T) {
+		path := writeFile(t, dir, "values.yml", `image: app
+env:
+  PORT: 80
+  HOST: example.com
+worker:
+  env:
+    - name: QUEUE
+`)
+		names, err := readDeployment(path, formatAuto)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"HOST", "PORT", "QUEUE"}, names)
+	})
+
+	t.Run("Happy, explicit format", func(t *testing.T) {
+		path := writeFile(t, dir, "values.txt", "env:\n  PORT: 80\n")
+		names, err := readDeployment(path, formatYAML)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"PORT"}, names)
+	})
+
+	t.Run("Unhappy, invalid yaml", func(t *testing.T) {
+		path := writeFile(t, dir, "invalid.yaml", "env: [")
+		_, err := readDeployment(path, formatAuto)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Unhappy, unknown format", func(t *testing.T) {
+		_, err := readDeployment("values.yaml", "toml")
+		assert.EqualError(t, err, `unknown format "toml"`)
+	})
+
+	t.Run("Unhappy, missing file", func(t *testing.T) {
+		_, err := readDeployment(dir+"/missing.env", formatAuto)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,70 @@
+// Command envcheck finds the envs a Go code base looks up with literal names
+// through the env package, and checks that a dotenv file, Kubernetes
+// manifest or Helm values file provides the required ones.
+//
+//	envcheck -profile production -file deploy/values.yaml ./...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("envcheck", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	file := flags.String("file", "", "dotenv file, Kubernetes manifest or Helm values file to check")
+	format := flags.String("format", formatAuto, "format of -file: auto, dotenv or yaml")
+	strict := flags.Bool("strict", false, "fail on provided envs no code uses")
+	deployedProfile := flags.String("profile", "", "profile of the deployment, envs of env.RequireIn listing it are required")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	usages, aliases, err := scan(patterns)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if *file == "" {
+		for _, usage := range usages {
+			fmt.Fprintf(stdout, "%s\t%s\t%s\n", usage.Name, usage.Requirement, usage.Position)
+		}
+		return 0
+	}
+
+	provided, err := readDeployment(*file, *format)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	report := check(usages, aliases, provided, *deployedProfile)
+	for _, usage := range report.Missing {
+		fmt.Fprintf(stdout, "missing required %s (%s)\n", usage.Name, usage.Position)
+	}
+	for _, usage := range report.MissingOptional {
+		fmt.Fprintf(stdout, "missing %s %s (%s)\n", usage.Requirement, usage.Name, usage.Position)
+	}
+	for _, name := range report.Unused {
+		fmt.Fprintf(stdout, "unused %s\n", name)
+	}
+	for _, rename := range report.Deprecated {
+		fmt.Fprintf(stdout, "deprecated %s, rename it %s\n", rename.Old, rename.New)
+	}
+
+	if report.Failed(*strict) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "envcheck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "main.go", `package main
+
+import "gitlab.com/gridwhizth/universe/env"
+
+func main() {
+	env.Require("PORT")
+	env.WarnIfEmpty("SENTRY_DSN")
+	env.RequireIn([]string{env.ProfileProduction}, "AUDIT_URL", "")
+	env.Alias("PORT", "HTTP_PORT")
+}
+`)
+	complete := writeFile(t, dir, "complete.env", "PORT=80\nSENTRY_DSN=dsn\nAUDIT_URL=url\n")
+	extra := writeFile(t, dir, "extra.env", "PORT=80\nSENTRY_DSN=dsn\nAUDIT_URL=url\nLEGACY=1\n")
+	development := writeFile(t, dir, "development.env", "PORT=80\nSENTRY_DSN=dsn\n")
+	missing := writeFile(t, dir, "missing.env", "LEGACY=1\n")
+
+	t.Run("Happy", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 0, run([]string{"-file", complete, dir}, &stdout, &stderr))
+		assert.Empty(t, stdout.String())
+	})
+
+	t.Run("Happy, list usages", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 0, run([]string{dir}, &stdout, &stderr))
+		assert.Contains(t, stdout.String(), "PORT\trequired\t")
+		assert.Contains(t, stdout.String(), "SENTRY_DSN\toptional\t")
+	})
+
+	t.Run("Happy, unused is not a failure", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 0, run([]string{"-file", extra, dir}, &stdout, &stderr))
+		assert.Equal(t, "unused LEGACY\n", stdout.String())
+	})
+
+	t.Run("Unhappy, unused in strict mode", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 1, run([]string{"-strict", "-file", extra, dir}, &stdout, &stderr))
+	})
+
+	t.Run("Unhappy, missing", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 1, run([]string{"-file", missing, dir}, &stdout, &stderr))
+		assert.Contains(t, stdout.String(), "missing required PORT (")
+		assert.Contains(t, stdout.String(), "missing optional SENTRY_DSN (")
+		assert.Contains(t, stdout.String(), "unused LEGACY\n")
+	})
+
+	t.Run("Happy, required in another profile", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 0, run([]string{"-profile", "development", "-file", development, dir}, &stdout, &stderr))
+		assert.Contains(t, stdout.String(), "missing optional AUDIT_URL (")
+	})
+
+	t.Run("Unhappy, required in the deployed profile", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 1, run([]string{"-profile", "production", "-file", development, dir}, &stdout, &stderr))
+		assert.Contains(t, stdout.String(), "missing required AUDIT_URL (")
+	})
+
+	t.Run("Happy, provided under a former name", func(t *testing.T) {
+		renamed := writeFile(t, dir, "renamed.env", "HTTP_PORT=80\nSENTRY_DSN=dsn\nAUDIT_URL=url\n")
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 0, run([]string{"-strict", "-file", renamed, dir}, &stdout, &stderr))
+		assert.Equal(t, "deprecated HTTP_PORT, rename it PORT\n", stdout.String())
+	})
+
+	t.Run("Unhappy, bad flag", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 2, run([]string{"-unknown"}, &stdout, &stderr))
+	})
+
+	t.Run("Unhappy, missing file", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 2, run([]string{"-file", dir + "/none.env", dir}, &stdout, &stderr))
+		assert.NotEmpty(t, stderr.String())
+	})
+}
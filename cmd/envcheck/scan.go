@@ -0,0 +1,202 @@
+package main
+
+import (
+	"gitlab.com/gridwhizth/universe/env"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const envImportPath = "gitlab.com/gridwhizth/universe/env"
+
+const (
+	required = "required"
+	optional = "optional"
+	profile  = "required in profile"
+)
+
+// Usage is an env looked up with a literal name through the env package.
+// Profiles are the profiles an env of env.RequireIn is required in, nil
+// when they are not literals.
+type Usage struct {
+	Name        string
+	Requirement string
+	Profiles    []string
+	Position    token.Position
+}
+
+var profileConstants = map[string]string{
+	"ProfileDevelopment": env.ProfileDevelopment,
+	"ProfileStaging":     env.ProfileStaging,
+	"ProfileProduction":  env.ProfileProduction,
+}
+
+// scan parses the Go files of the given patterns, a pattern ending with
+// /... includes its subdirectories. Test files, vendor, testdata and hidden
+// directories are skipped. It returns the usages and the former names of
+// the envs declared with env.Alias.
+func scan(patterns []string) ([]Usage, map[string][]string, error) {
+	var usages []Usage
+	aliases := map[string][]string{}
+	fset := token.NewFileSet()
+	for _, pattern := range patterns {
+		dir, recursive := pattern, false
+		if strings.HasSuffix(pattern, "/...") || pattern == "..." {
+			dir, recursive = strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/"), true
+			if dir == "" {
+				dir = "."
+			}
+		}
+
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if path != dir && (!recursive || skipDir(info.Name())) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+			usages = append(usages, scanFile(fset, file, aliases)...)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return usages, aliases, nil
+}
+
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// scanFile returns the usages of a file and adds its literal env.Alias
+// declarations to aliases.
+func scanFile(fset *token.FileSet, file *ast.File, aliases map[string][]string) []Usage {
+	packageName := ""
+	for _, imp := range file.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path == envImportPath {
+			packageName = "env"
+			if imp.Name != nil {
+				packageName = imp.Name.Name
+			}
+		}
+	}
+	if packageName == "" || packageName == "_" {
+		return nil
+	}
+
+	var usages []Usage
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != packageName {
+			return true
+		}
+		if selector.Sel.Name == "Alias" {
+			if names, ok := literalStrings(call.Args); ok && len(names) > 1 {
+				aliases[names[0]] = append(aliases[names[0]], names[1:]...)
+			}
+			return true
+		}
+
+		requirement, argument := requirementOf(selector.Sel.Name)
+		if requirement == "" || len(call.Args) <= argument {
+			return true
+		}
+		names, ok := literalStrings(call.Args[argument : argument+1])
+		if !ok {
+			return true
+		}
+		name := names[0]
+		usage := Usage{Name: name, Requirement: requirement, Position: fset.Position(call.Pos())}
+		if requirement == profile {
+			usage.Profiles = literalProfiles(call.Args[0], packageName)
+		}
+		usages = append(usages, usage)
+		return true
+	})
+	return usages
+}
+
+// literalStrings returns the values of string literals, false when an
+// expression is not one.
+func literalStrings(exprs []ast.Expr) ([]string, bool) {
+	values := make([]string, len(exprs))
+	for i, expr := range exprs {
+		literal, ok := expr.(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return nil, false
+		}
+		value, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			return nil, false
+		}
+		values[i] = value
+	}
+	return values, true
+}
+
+// literalProfiles returns the profiles of a []string literal made of string
+// literals and env.Profile constants, nil for any other expression.
+func literalProfiles(expr ast.Expr, packageName string) []string {
+	composite, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	profiles := make([]string, 0, len(composite.Elts))
+	for _, element := range composite.Elts {
+		switch element := element.(type) {
+		case *ast.BasicLit:
+			value, err := strconv.Unquote(element.Value)
+			if element.Kind != token.STRING || err != nil {
+				return nil
+			}
+			profiles = append(profiles, value)
+		case *ast.SelectorExpr:
+			ident, ok := element.X.(*ast.Ident)
+			value, known := profileConstants[element.Sel.Name]
+			if !ok || ident.Name != packageName || !known {
+				return nil
+			}
+			profiles = append(profiles, value)
+		default:
+			return nil
+		}
+	}
+	return profiles
+}
+
+// requirementOf returns the requirement of an env function and the index
+// of the argument holding the env name.
+func requirementOf(function string) (string, int) {
+	switch {
+	case function == "RequireIn":
+		return profile, 1
+	case strings.HasPrefix(function, "Require"):
+		return required, 0
+	case strings.HasPrefix(function, "WarnIfEmpty"), strings.HasPrefix(function, "Default"):
+		return optional, 0
+	}
+	return "", 0
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestScan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "envcheck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "main.go", `package main
+
+import "gitlab.com/gridwhizth/universe/env"
+
+var name = "DYNAMIC"
+
+var profiles = []string{"production"}
+
+func main() {
+	env.Require("DATABASE_URL")
+	env.RequireInt("PORT")
+	env.WarnIfEmpty("SENTRY_DSN")
+	env.DefaultDuration("TIMEOUT", 0)
+	env.RequireIn([]string{env.ProfileProduction, "staging"}, "API_KEY", "")
+	env.RequireIn(profiles, "AUDIT_URL", "")
+	env.Require(name)
+	env.Alias("DATABASE_URL", "DB_URL", "DATABASE")
+	env.Alias(name, "IGNORED")
+	env.Assert()
+}
+`)
+	writeFile(t, dir, "config/config.go", `package config
+
+import config "gitlab.com/gridwhizth/universe/env"
+
+var Host = config.Default("HOST", "localhost")
+`)
+	writeFile(t, dir, "other/other.go", `package other
+
+import "fmt"
+
+var env = struct{ Require func(string) string }{}
+var Value = env.Require("NOT_ENV")
+var _ = fmt.Sprint()
+`)
+	writeFile(t, dir, "main_test.go", `package main
+
+import "gitlab.com/gridwhizth/universe/env"
+
+var _ = env.Require("TEST_ONLY")
+`)
+	writeFile(t, dir, "vendor/lib/lib.go", `package lib
+
+import "gitlab.com/gridwhizth/universe/env"
+
+var _ = env.Require("VENDORED")
+`)
+
+	t.Run("Happy", func(t *testing.T) {
+		usages, aliases, err := scan([]string{dir + "/..."})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]string{"DATABASE_URL": {"DB_URL", "DATABASE"}}, aliases)
+
+		requirements := map[string]string{}
+		for _, usage := range usages {
+			requirements[usage.Name] = usage.Requirement
+		}
+		assert.Equal(t, map[string]string{
+			"DATABASE_URL": required,
+			"PORT":         required,
+			"SENTRY_DSN":   optional,
+			"TIMEOUT":      optional,
+			"API_KEY":      profile,
+			"AUDIT_URL":    profile,
+			"HOST":         optional,
+		}, requirements)
+	})
+
+	t.Run("Happy, not recursive", func(t *testing.T) {
+		usages, _, err := scan([]string{dir})
+		assert.Nil(t, err)
+		assert.Len(t, usages, 6)
+		assert.Equal(t, filepath.Join(dir, "main.go"), usages[0].Position.Filename)
+		assert.Equal(t, 10, usages[0].Position.Line)
+		assert.Equal(t, []string{"production", "staging"}, usages[4].Profiles)
+		assert.Nil(t, usages[5].Profiles)
+	})
+
+	t.Run("Unhappy, syntax error", func(t *testing.T) {
+		writeFile(t, dir, "broken/broken.go", "package broken\nfunc {")
+		_, _, err := scan([]string{dir + "/..."})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package env
+
+import (
+	"fmt"
+)
+
+func Alias(envName string, oldNames ...string) {
+	defaultRegistry.Alias(envName, oldNames...)
+}
+
+// Alias declares the former names of envName. A lookup of envName falls
+// back to them with a deprecation warning, and setting one of them to a
+// different value than envName is reported as a failure.
+func (r *Registry) Alias(envName string, oldNames ...string) {
+	envName = r.name(envName)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.aliases == nil {
+		r.aliases = map[string][]string{}
+	}
+	for _, oldName := range oldNames {
+		r.aliases[envName] = append(r.aliases[envName], r.name(oldName))
+	}
+}
+
+func (r *Registry) aliasesOf(envName string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.aliases[envName]
+}
+
+func (r *Registry) resolveAliases(envName string) resolution {
+	res := r.resolveFile(envName)
+	for _, oldName := range r.aliasesOf(envName) {
+		old := r.resolveFile(oldName)
+		if !old.found {
+			continue
+		}
+		res.issues = append(res.issues, old.issues...)
+
+		switch {
+		case !res.found:
+			res.value, res.source, res.found = old.value, old.source, true
+			res.issues = append(res.issues, deprecatedIssue(envName, oldName))
+		case res.value != old.value:
+			res.issues = append(res.issues, failureIssue(envName, KindInvalid, fmt.Sprintf("%s env and %s env are both set with different values.", envName, oldName)))
+		default:
+			res.issues = append(res.issues, deprecatedIssue(envName, oldName))
+		}
+	}
+	return res
+}
+
+func deprecatedIssue(envName string, oldName string) Issue {
+	return newIssue(oldName, nil, KindDeprecated, fmt.Sprintf("%s env is deprecated, use %s env instead.", oldName, envName))
+}
@@ -0,0 +1,58 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAlias(t *testing.T) {
+	t.Run("Happy, new name is set", func(t *testing.T) {
+		registry := NewRegistry(Map("test", map[string]string{"ALIAS_DATABASE_URL": "postgres://new"}))
+		registry.Alias("ALIAS_DATABASE_URL", "ALIAS_DB_URL")
+
+		assert.Equal(t, "postgres://new", registry.Require("ALIAS_DATABASE_URL"))
+		assert.Nil(t, registry.AssertErr())
+		assert.Empty(t, registry.Snapshot().Warnings)
+	})
+
+	t.Run("Happy, falls back to the old name with a warning", func(t *testing.T) {
+		registry := NewRegistry(Map("legacy", map[string]string{"ALIAS_DB_URL": "postgres://old"}))
+		registry.Alias("ALIAS_DATABASE_URL", "ALIAS_DATABASE", "ALIAS_DB_URL")
+
+		assert.Equal(t, "postgres://old", registry.Require("ALIAS_DATABASE_URL", "database url"))
+		assert.Equal(t, "legacy", registry.SourceOf("ALIAS_DATABASE_URL"))
+		assert.Empty(t, registry.Snapshot().Failures)
+
+		warnings := registry.Snapshot().Warnings
+		assert.Len(t, warnings, 1)
+		assert.Equal(t, "ALIAS_DB_URL env is deprecated, use ALIAS_DATABASE_URL env instead. (database url)", warnings[0].String())
+		assert.Equal(t, "ALIAS_DB_URL", warnings[0].Name)
+		assert.Equal(t, KindDeprecated, warnings[0].Kind)
+	})
+
+	t.Run("Happy, both names set with the same value", func(t *testing.T) {
+		registry := NewRegistry(Map("test", map[string]string{"ALIAS_DATABASE_URL": "postgres://db", "ALIAS_DB_URL": "postgres://db"}))
+		registry.Alias("ALIAS_DATABASE_URL", "ALIAS_DB_URL")
+
+		assert.Equal(t, "postgres://db", registry.Require("ALIAS_DATABASE_URL"))
+		assert.Empty(t, registry.Snapshot().Failures)
+		assert.Len(t, registry.Snapshot().Warnings, 1)
+	})
+
+	t.Run("Unhappy, both names set with different values", func(t *testing.T) {
+		registry := NewRegistry(Map("test", map[string]string{"PAYMENT_DATABASE_URL": "postgres://new", "PAYMENT_DB_URL": "postgres://old"}))
+		payment := registry.WithPrefix("PAYMENT_")
+		payment.Alias("DATABASE_URL", "DB_URL")
+
+		_ = payment.Require("DATABASE_URL")
+		assert.Equal(t, "PAYMENT_DATABASE_URL env and PAYMENT_DB_URL env are both set with different values.", joinIssues(registry.Snapshot().Failures))
+	})
+
+	t.Run("Unhappy, no name is set", func(t *testing.T) {
+		registry := NewRegistry(Map("test", nil))
+		registry.Alias("ALIAS_DATABASE_URL", "ALIAS_DB_URL")
+
+		_ = registry.Require("ALIAS_DATABASE_URL")
+		assert.Equal(t, "ALIAS_DATABASE_URL env is required.", joinIssues(registry.Snapshot().Failures))
+	})
+}
@@ -0,0 +1,90 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/gridwhizth/universe/utils"
+	"io/ioutil"
+	"strings"
+)
+
+// EncryptedPrefix marks a value encrypted with utils.EncryptAES, the hex
+// encoded ciphertext follows it.
+const EncryptedPrefix = "enc:"
+
+// DefaultDecryptionKeyEnv holds the hex encoded key used to decrypt the
+// encrypted values unless another env or a key file is designated.
+const DefaultDecryptionKeyEnv = "ENV_MASTER_KEY"
+
+func UseDecryptionKeyEnv(envName string) {
+	defaultRegistry.UseDecryptionKeyEnv(envName)
+}
+
+func UseDecryptionKeyFile(path string) {
+	defaultRegistry.UseDecryptionKeyFile(path)
+}
+
+// UseDecryptionKeyEnv designates the env holding the decryption key, the
+// _FILE fallback applies to it as well.
+func (r *Registry) UseDecryptionKeyEnv(envName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decryptionKeyEnv = envName
+	r.decryptionKeyFile = ""
+}
+
+// UseDecryptionKeyFile designates a file holding the decryption key.
+func (r *Registry) UseDecryptionKeyFile(path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decryptionKeyFile = path
+}
+
+// decrypt replaces an encrypted value by its plaintext. The reported issues
+// never contain the ciphertext or the key.
+func (r *Registry) decrypt(envName string, res resolution) resolution {
+	if !strings.HasPrefix(res.value, EncryptedPrefix) {
+		return res
+	}
+
+	key, location, err := r.decryptionKey()
+	if err != nil {
+		res.value = ""
+		res.issues = append(res.issues, failureIssue(envName, KindInvalid, fmt.Sprintf("%s env cannot be decrypted, %s.", envName, err)))
+		return res
+	}
+	if key == "" {
+		res.value = ""
+		res.issues = append(res.issues, failureIssue(envName, KindInvalid, fmt.Sprintf("%s env cannot be decrypted, no key is set in %s.", envName, location)))
+		return res
+	}
+
+	plaintext, err := utils.DecryptAES(strings.TrimPrefix(res.value, EncryptedPrefix), key)
+	if err != nil {
+		res.value = ""
+		res.issues = append(res.issues, failureIssue(envName, KindInvalid, fmt.Sprintf("%s env cannot be decrypted with the key set in %s.", envName, location)))
+		return res
+	}
+	res.value = *plaintext
+	return res
+}
+
+func (r *Registry) decryptionKey() (string, string, error) {
+	r.mu.Lock()
+	keyEnv, keyFile := r.decryptionKeyEnv, r.decryptionKeyFile
+	r.mu.Unlock()
+
+	if keyFile != "" {
+		content, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return "", keyFile, errors.New("the key file is unreadable")
+		}
+		return strings.TrimSpace(string(content)), keyFile, nil
+	}
+
+	res := r.resolveFile(keyEnv)
+	if len(res.issues) > 0 {
+		return "", keyEnv + " env", fmt.Errorf("%s env is misconfigured", keyEnv)
+	}
+	return strings.TrimSpace(res.value), keyEnv + " env", nil
+}
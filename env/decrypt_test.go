@@ -0,0 +1,116 @@
+package env
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/gridwhizth/universe/utils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecrypt(t *testing.T) {
+	bytes := make([]byte, 32)
+	_, _ = rand.Read(bytes)
+	key := hex.EncodeToString(bytes)
+	ciphertext, err := utils.EncryptAES("s3cret", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted := EncryptedPrefix + fmt.Sprintf("%x", ciphertext)
+
+	init := func() {
+		_ = os.Setenv("DECRYPT_PASSWORD", encrypted)
+	}
+	reset := func() {
+		_ = os.Unsetenv("DECRYPT_PASSWORD")
+		_ = os.Unsetenv(DefaultDecryptionKeyEnv)
+		_ = os.Unsetenv("DECRYPT_KEY")
+	}
+
+	t.Run("Happy, key from the default env", func(t *testing.T) {
+		init()
+		defer reset()
+		_ = os.Setenv(DefaultDecryptionKeyEnv, key)
+
+		registry := NewRegistry()
+		assert.Equal(t, "s3cret", registry.RequireSecret("DECRYPT_PASSWORD").Value())
+		assert.Empty(t, registry.Snapshot().Failures)
+	})
+
+	t.Run("Happy, key from a designated env", func(t *testing.T) {
+		init()
+		defer reset()
+		_ = os.Setenv("DECRYPT_KEY", key)
+
+		registry := NewRegistry()
+		registry.UseDecryptionKeyEnv("DECRYPT_KEY")
+		assert.Equal(t, "s3cret", registry.Require("DECRYPT_PASSWORD"))
+	})
+
+	t.Run("Happy, key from a file", func(t *testing.T) {
+		init()
+		defer reset()
+		dir, err := ioutil.TempDir("", "decrypt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "master.key")
+		_ = ioutil.WriteFile(path, []byte(key+"\n"), 0600)
+
+		registry := NewRegistry()
+		registry.UseDecryptionKeyFile(path)
+		assert.Equal(t, "s3cret", registry.Default("DECRYPT_PASSWORD", ""))
+	})
+
+	t.Run("Happy, plain values are untouched", func(t *testing.T) {
+		_ = os.Setenv("DECRYPT_PASSWORD", "plain")
+		defer reset()
+
+		registry := NewRegistry()
+		assert.Equal(t, "plain", registry.Require("DECRYPT_PASSWORD"))
+	})
+
+	t.Run("Unhappy, no key", func(t *testing.T) {
+		init()
+		defer reset()
+
+		registry := NewRegistry()
+		assert.Equal(t, "", registry.Require("DECRYPT_PASSWORD", "database password"))
+		failures := registry.Snapshot().Failures
+		assert.Equal(t, "DECRYPT_PASSWORD env cannot be decrypted, no key is set in ENV_MASTER_KEY env. (database password)", joinIssues(failures))
+	})
+
+	t.Run("Unhappy, wrong key or ciphertext", func(t *testing.T) {
+		init()
+		defer reset()
+		other := make([]byte, 32)
+		_, _ = rand.Read(other)
+		_ = os.Setenv(DefaultDecryptionKeyEnv, hex.EncodeToString(other))
+
+		registry := NewRegistry()
+		_ = registry.Require("DECRYPT_PASSWORD")
+		_ = os.Setenv("DECRYPT_PASSWORD", EncryptedPrefix+"zz")
+		_ = registry.Require("DECRYPT_PASSWORD")
+
+		message := joinIssues(registry.Snapshot().Failures)
+		assert.Equal(t, "DECRYPT_PASSWORD env cannot be decrypted with the key set in ENV_MASTER_KEY env.\n"+
+			"DECRYPT_PASSWORD env cannot be decrypted with the key set in ENV_MASTER_KEY env.", message)
+		assert.NotContains(t, message, encrypted)
+		assert.NotContains(t, message, hex.EncodeToString(other))
+	})
+
+	t.Run("Unhappy, key file unreadable", func(t *testing.T) {
+		init()
+		defer reset()
+
+		registry := NewRegistry()
+		registry.UseDecryptionKeyFile(filepath.Join(os.TempDir(), "missing-master.key"))
+		_ = registry.Require("DECRYPT_PASSWORD")
+		assert.Contains(t, joinIssues(registry.Snapshot().Failures), "DECRYPT_PASSWORD env cannot be decrypted, the key file is unreadable.")
+	})
+}
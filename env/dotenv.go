@@ -0,0 +1,216 @@
+package env
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+var dotenvKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+func LoadDotenv(paths ...string) error {
+	return defaultRegistry.LoadDotenv(paths...)
+}
+
+func SourceOf(envName string) string {
+	return defaultRegistry.SourceOf(envName)
+}
+
+// LoadDotenv adds the given dotenv files below the sources of r. With the
+// default sources the process environment always takes precedence over a
+// file, and a file takes precedence over the files given or loaded after it.
+// The overlay of a file for the active profile, such as .env.production,
+// is loaded right above it when it exists.
+func (r *Registry) LoadDotenv(paths ...string) error {
+	sources := make([]Source, 0, len(paths))
+	for _, path := range paths {
+		for _, file := range append(r.profileOverlays(path), path) {
+			source, err := Dotenv(file)
+			if err != nil {
+				return err
+			}
+			sources = append(sources, source)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append(r.sources, sources...)
+	return nil
+}
+
+// SourceOf returns the name of the source holding envName, the path of a
+// secret file for the _FILE fallback, or an empty string when it is not set.
+func (r *Registry) SourceOf(envName string) string {
+	return r.resolve(r.name(envName)).source
+}
+
+// ReadDotenv returns the values of a dotenv file.
+func ReadDotenv(path string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseDotenv(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+func parseDotenv(content string) (map[string]string, error) {
+	p := dotenvParser{content: strings.Replace(content, "\r\n", "\n", -1), line: 1}
+	values := map[string]string{}
+	for {
+		p.skipBlank()
+		if p.done() {
+			return values, nil
+		}
+		if p.peek() == '#' {
+			p.skipLine()
+			continue
+		}
+
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+}
+
+type dotenvParser struct {
+	content string
+	pos     int
+	line    int
+}
+
+func (p *dotenvParser) done() bool {
+	return p.pos >= len(p.content)
+}
+
+func (p *dotenvParser) peek() byte {
+	return p.content[p.pos]
+}
+
+func (p *dotenvParser) next() byte {
+	c := p.content[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+func (p *dotenvParser) skipBlank() {
+	for !p.done() && strings.IndexByte(" \t\n", p.peek()) >= 0 {
+		p.next()
+	}
+}
+
+func (p *dotenvParser) skipSpaces() {
+	for !p.done() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.next()
+	}
+}
+
+func (p *dotenvParser) skipLine() {
+	for !p.done() && p.next() != '\n' {
+	}
+}
+
+func (p *dotenvParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *dotenvParser) key() (string, error) {
+	end := strings.IndexAny(p.content[p.pos:], "=\n")
+	if end < 0 || p.content[p.pos+end] != '=' {
+		return "", p.errorf("expected KEY=value")
+	}
+	key := strings.TrimSpace(p.content[p.pos : p.pos+end])
+	if strings.HasPrefix(key, "export ") || strings.HasPrefix(key, "export\t") {
+		key = strings.TrimSpace(key[len("export"):])
+	}
+	if !dotenvKey.MatchString(key) {
+		return "", p.errorf("invalid key %q", key)
+	}
+	p.pos += end + 1
+	return key, nil
+}
+
+func (p *dotenvParser) value() (string, error) {
+	start := p.pos
+	p.skipSpaces()
+	if p.done() {
+		return "", nil
+	}
+	// like an inline comment, a # after spaces starts a comment
+	if p.pos > start && p.peek() == '#' {
+		p.skipLine()
+		return "", nil
+	}
+
+	switch quote := p.peek(); quote {
+	case '"', '\'':
+		p.next()
+		value, err := p.quoted(quote)
+		if err != nil {
+			return "", err
+		}
+		p.skipSpaces()
+		if !p.done() && p.peek() != '\n' && p.peek() != '#' {
+			return "", p.errorf("unexpected character after quoted value")
+		}
+		p.skipLine()
+		return value, nil
+	default:
+		end := strings.IndexByte(p.content[p.pos:], '\n')
+		if end < 0 {
+			end = len(p.content) - p.pos
+		}
+		value := p.content[p.pos : p.pos+end]
+		p.pos += end
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
+
+// quoted reads up to the closing quote, a value may span several lines.
+// Escapes are only interpreted between double quotes.
+func (p *dotenvParser) quoted(quote byte) (string, error) {
+	var value strings.Builder
+	start := p.line
+	for !p.done() {
+		c := p.next()
+		switch {
+		case c == quote:
+			return value.String(), nil
+		case c == '\\' && quote == '"' && !p.done():
+			escaped := p.next()
+			switch escaped {
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			case '"', '\\', '$':
+				value.WriteByte(escaped)
+			default:
+				value.WriteByte('\\')
+				value.WriteByte(escaped)
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("line %d: unterminated quoted value", start)
+}
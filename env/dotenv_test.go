@@ -0,0 +1,110 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseDotenv(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		values, err := parseDotenv(`
+# comment
+PLAIN=value
+export EXPORTED=exported
+SPACED = spaced value   # inline comment
+EMPTY=
+SINGLE='literal \n ${VALUE}'
+DOUBLE="escaped \"quote\"\ttab\\n"
+MULTI="first
+second"
+HASH=abc#def
+COMMENTED= # only a comment
+COLOR=#fff
+`)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{
+			"PLAIN":     "value",
+			"EXPORTED":  "exported",
+			"SPACED":    "spaced value",
+			"EMPTY":     "",
+			"SINGLE":    `literal \n ${VALUE}`,
+			"DOUBLE":    "escaped \"quote\"\ttab\\n",
+			"MULTI":     "first\nsecond",
+			"HASH":      "abc#def",
+			"COMMENTED": "",
+			"COLOR":     "#fff",
+		}, values)
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		_, err := parseDotenv("KEY=value\nNOT A PAIR\n")
+		assert.EqualError(t, err, "line 2: expected KEY=value")
+
+		_, err = parseDotenv("1KEY=value")
+		assert.EqualError(t, err, `line 1: invalid key "1KEY"`)
+
+		_, err = parseDotenv("KEY=value\nQUOTED=\"never closed\n")
+		assert.EqualError(t, err, "line 2: unterminated quoted value")
+
+		_, err = parseDotenv("QUOTED='value' trailing")
+		assert.EqualError(t, err, "line 1: unexpected character after quoted value")
+	})
+}
+
+func TestLoadDotenv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dotenv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	local := writeFile(t, dir, ".env.local", "DOTENV_PORT=9090\n")
+	shared := writeFile(t, dir, ".env", "DOTENV_PORT=8080\nDOTENV_HOST=localhost\nDOTENV_PROCESS=file\n")
+
+	t.Run("Happy", func(t *testing.T) {
+		_ = os.Setenv("DOTENV_PROCESS", "process")
+		defer os.Unsetenv("DOTENV_PROCESS")
+
+		registry := NewRegistry()
+		assert.Nil(t, registry.LoadDotenv(local, shared))
+
+		assert.Equal(t, 9090, registry.RequireInt("DOTENV_PORT"))
+		assert.Equal(t, local, registry.SourceOf("DOTENV_PORT"))
+		assert.Equal(t, "localhost", registry.Require("DOTENV_HOST"))
+		assert.Equal(t, shared, registry.SourceOf("DOTENV_HOST"))
+		assert.Equal(t, "process", registry.Require("DOTENV_PROCESS"))
+		assert.Equal(t, ProcessSource, registry.SourceOf("DOTENV_PROCESS"))
+		assert.Equal(t, "", registry.SourceOf("DOTENV_MISSING"))
+		assert.Empty(t, registry.Snapshot().Failures)
+	})
+
+	t.Run("Unhappy, invalid value reports its source", func(t *testing.T) {
+		registry := NewRegistry()
+		assert.Nil(t, registry.LoadDotenv(shared))
+		_ = registry.RequireInt("DOTENV_HOST")
+
+		failures := registry.Snapshot().Failures
+		assert.Equal(t, "DOTENV_HOST env must be an integer.", failures[0].String())
+		assert.Equal(t, shared, failures[0].Source)
+	})
+
+	t.Run("Unhappy, file cannot be loaded", func(t *testing.T) {
+		registry := NewRegistry()
+		err := registry.LoadDotenv(filepath.Join(dir, "missing"))
+		assert.True(t, os.IsNotExist(err))
+
+		invalid := writeFile(t, dir, ".env.invalid", "INVALID")
+		assert.EqualError(t, registry.LoadDotenv(invalid), invalid+": line 1: expected KEY=value")
+	})
+}
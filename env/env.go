@@ -2,52 +2,164 @@ package env
 
 import (
 	"fmt"
-	"log"
-	"strings"
-	"syscall"
+	"sync"
 )
 
-var panickingMessages []string
-var warningMessages []string
+// Registry collects the messages of env lookups until they are reported by
+// Assert. It is safe for concurrent use, the package level functions use a
+// default instance.
+type Registry struct {
+	*shared
+	prefix string
+}
 
-func Require(envName string, description ...string) string {
-	env, found := syscall.Getenv(envName)
-	if !found {
-		message := fmt.Sprintf("%s env is required.", envName)
-		message = prependDescription(message, description)
-		prePanic(message)
+// shared is the state of a Registry, shared with the views returned by
+// WithPrefix.
+type shared struct {
+	mu            sync.Mutex
+	failures      []Issue
+	warnings      []Issue
+	sources       []Source
+	variables     map[string]Variable
+	variableNames []string
+	rules         map[string][]Rule
+	aliases       map[string][]string
+
+	decryptionKeyEnv  string
+	decryptionKeyFile string
+	profileEnv        string
+	log               Logger
+}
+
+// State is a copy of the issues pending in a Registry.
+type State struct {
+	Failures []Issue
+	Warnings []Issue
+}
+
+// ProcessSource is the source of the values read from the process environment.
+const ProcessSource = "process environment"
+
+var defaultRegistry = NewRegistry()
+
+// NewRegistry returns a Registry looking up the given sources in order, the
+// process environment when none is given.
+func NewRegistry(sources ...Source) *Registry {
+	if len(sources) == 0 {
+		sources = []Source{Process()}
 	}
-	return env
+	return &Registry{shared: &shared{
+		sources:          sources,
+		decryptionKeyEnv: DefaultDecryptionKeyEnv,
+		profileEnv:       DefaultProfileEnv,
+	}}
+}
+
+func Require(envName string, description ...string) string {
+	return defaultRegistry.Require(envName, description...)
 }
 
 func WarnIfEmpty(envName string, description ...string) string {
-	env, found := syscall.Getenv(envName)
-	if !found {
-		message := fmt.Sprintf("%s env is empty, it may be needed.", envName)
-		message = prependDescription(message, description)
-		preWarn(message)
-	}
-	return env
+	return defaultRegistry.WarnIfEmpty(envName, description...)
 }
 
 func Default(envName string, defaultValue string) string {
-	env, found := syscall.Getenv(envName)
-	if !found {
-		return defaultValue
-	}
-	return env
+	return defaultRegistry.Default(envName, defaultValue)
 }
 
 func Assert() {
-	if len(warningMessages) > 0 {
-		log.Println(strings.Join(warningMessages, "\n"))
+	defaultRegistry.Assert()
+}
+
+func AssertErr() error {
+	return defaultRegistry.AssertErr()
+}
+
+func Reset() {
+	defaultRegistry.Reset()
+}
+
+func Snapshot() State {
+	return defaultRegistry.Snapshot()
+}
+
+func Restore(state State) {
+	defaultRegistry.Restore(state)
+}
+
+func (r *Registry) Require(envName string, description ...string) string {
+	return r.requireParsed(envName, stringParser, description).(string)
+}
+
+func (r *Registry) WarnIfEmpty(envName string, description ...string) string {
+	return r.warnIfEmptyParsed(envName, stringParser, description).(string)
+}
+
+func (r *Registry) Default(envName string, defaultValue string) string {
+	return r.defaultParsed(envName, stringParser, defaultValue, nil).(string)
+}
+
+// Assert logs the pending warnings and failures, then panics with the
+// failures.
+func (r *Registry) Assert() {
+	if err := r.AssertErr(); err != nil {
+		failures := err.(*AssertionError).Failures()
+		r.logger().Log(SeverityFailure, failures)
+		panic(joinIssues(failures))
+	}
+}
+
+// AssertErr logs the pending warnings like Assert but returns the pending
+// failures as an *AssertionError instead of panicking. The pending issues are
+// cleared only when there is no failure to report.
+func (r *Registry) AssertErr() error {
+	r.mu.Lock()
+	state := r.state()
+	if len(state.Failures) == 0 {
+		r.resetState()
+	}
+	r.mu.Unlock()
+
+	if len(state.Warnings) > 0 {
+		r.logger().Log(SeverityWarning, state.Warnings)
 	}
 
-	if len(panickingMessages) > 0 {
-		log.Panic(strings.Join(panickingMessages, "\n"))
+	if len(state.Failures) > 0 {
+		return &AssertionError{Issues: append(state.Failures, state.Warnings...)}
 	}
+	return nil
+}
+
+// Reset clears the pending issues, the inventory, the validation rules and
+// the aliases.
+func (r *Registry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resetState()
+	r.variables = nil
+	r.variableNames = nil
+	r.rules = nil
+	r.aliases = nil
+}
+
+func (r *Registry) Snapshot() State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.state()
+}
 
-	resetState()
+func (r *Registry) Restore(state State) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append([]Issue(nil), state.Failures...)
+	r.warnings = append([]Issue(nil), state.Warnings...)
+}
+
+func (r *Registry) lookup(envName string) (string, string, bool) {
+	r.mu.Lock()
+	sources := r.sources
+	r.mu.Unlock()
+	return lookupIn(chainSource(sources), envName)
 }
 
 func prependDescription(message string, description []string) string {
@@ -57,15 +169,28 @@ func prependDescription(message string, description []string) string {
 	return message
 }
 
-func prePanic(message string) {
-	panickingMessages = append(panickingMessages, message)
+func (r *Registry) prePanic(issue Issue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	issue.Severity = SeverityFailure
+	r.failures = append(r.failures, issue)
 }
 
-func preWarn(message string) {
-	warningMessages = append(warningMessages, message)
+func (r *Registry) preWarn(issue Issue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	issue.Severity = SeverityWarning
+	r.warnings = append(r.warnings, issue)
+}
+
+func (r *Registry) state() State {
+	return State{
+		Failures: append([]Issue(nil), r.failures...),
+		Warnings: append([]Issue(nil), r.warnings...),
+	}
 }
 
-func resetState() {
-	panickingMessages = nil
-	warningMessages = nil
+func (r *Registry) resetState() {
+	r.failures = nil
+	r.warnings = nil
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"log"
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -14,14 +15,14 @@ func TestRequire(t *testing.T) {
 	}
 	reset := func() {
 		_ = os.Unsetenv("REQUIRED_KEY")
-		resetState()
+		Reset()
 	}
 
 	t.Run("Happy", func(t *testing.T) {
 		init()
 		val := Require("REQUIRED_KEY")
 		assert.Equal(t, val, "value")
-		assert.Len(t, panickingMessages, 0)
+		assert.Len(t, defaultRegistry.failures, 0)
 		reset()
 	})
 
@@ -32,8 +33,8 @@ func TestRequire(t *testing.T) {
 
 		assert.Equal(t, val, "")
 		assert.Equal(t, val2, "")
-		assert.Equal(t, panickingMessages[0], "REQUIRED_KEY_2 env is required.")
-		assert.Equal(t, panickingMessages[1], "REQUIRED_KEY_3 env is required. (this env is important)")
+		assert.Equal(t, defaultRegistry.failures[0].String(), "REQUIRED_KEY_2 env is required.")
+		assert.Equal(t, defaultRegistry.failures[1].String(), "REQUIRED_KEY_3 env is required. (this env is important)")
 		reset()
 	})
 }
@@ -44,14 +45,14 @@ func TestWarnIfEmpty(t *testing.T) {
 	}
 	reset := func() {
 		_ = os.Unsetenv("WARN_KEY")
-		resetState()
+		Reset()
 	}
 
 	t.Run("Happy", func(t *testing.T) {
 		init()
 		val := WarnIfEmpty("WARN_KEY")
 		assert.Equal(t, val, "value")
-		assert.Len(t, warningMessages, 0)
+		assert.Len(t, defaultRegistry.warnings, 0)
 		reset()
 	})
 
@@ -62,8 +63,8 @@ func TestWarnIfEmpty(t *testing.T) {
 
 		assert.Equal(t, val, "")
 		assert.Equal(t, val2, "")
-		assert.Equal(t, warningMessages[0], "WARN_KEY_2 env is empty, it may be needed.")
-		assert.Equal(t, warningMessages[1], "WARN_KEY_3 env is empty, it may be needed. (this env may be important)")
+		assert.Equal(t, defaultRegistry.warnings[0].String(), "WARN_KEY_2 env is empty, it may be needed.")
+		assert.Equal(t, defaultRegistry.warnings[1].String(), "WARN_KEY_3 env is empty, it may be needed. (this env may be important)")
 		reset()
 	})
 }
@@ -74,7 +75,7 @@ func TestDefault(t *testing.T) {
 	}
 	reset := func() {
 		_ = os.Unsetenv("DEFAULT_KEY")
-		resetState()
+		Reset()
 	}
 
 	t.Run("Happy", func(t *testing.T) {
@@ -103,15 +104,15 @@ func TestAssert(t *testing.T) {
 		buffer.Reset()
 
 		// Assign message to be logged
-		preWarn("Message 1.")
-		preWarn("Message 2.")
+		defaultRegistry.preWarn(Issue{Message: "Message 1."})
+		defaultRegistry.preWarn(Issue{Message: "Message 2."})
 		Assert()
 		assert.Contains(t, buffer.String(), "Message 1.\nMessage 2.")
 		buffer.Reset()
 
 		// Assign message to be panicked
-		prePanic("Message 1.")
-		prePanic("Message 2.")
+		defaultRegistry.prePanic(Issue{Message: "Message 1."})
+		defaultRegistry.prePanic(Issue{Message: "Message 2."})
 		assert.Panics(t, func() {
 			Assert()
 		})
@@ -119,3 +120,55 @@ func TestAssert(t *testing.T) {
 		buffer.Reset()
 	})
 }
+
+func TestRegistry(t *testing.T) {
+	t.Run("Happy, registries are independent", func(t *testing.T) {
+		Reset()
+		first := NewRegistry()
+		second := NewRegistry()
+
+		_ = first.Require("REGISTRY_KEY")
+		_ = second.WarnIfEmpty("REGISTRY_KEY")
+
+		assert.Equal(t, "REGISTRY_KEY env is required.", joinIssues(first.Snapshot().Failures))
+		assert.Empty(t, first.Snapshot().Warnings)
+		assert.Empty(t, second.Snapshot().Failures)
+		assert.Equal(t, "REGISTRY_KEY env is empty, it may be needed.", joinIssues(second.Snapshot().Warnings))
+		assert.Empty(t, Snapshot().Failures)
+
+		first.Reset()
+		assert.Empty(t, first.Snapshot().Failures)
+		assert.Len(t, second.Snapshot().Warnings, 1)
+	})
+
+	t.Run("Happy, concurrent lookups", func(t *testing.T) {
+		registry := NewRegistry()
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = registry.Require("REGISTRY_KEY")
+				_ = registry.WarnIfEmptyInt("REGISTRY_KEY")
+			}()
+		}
+		wg.Wait()
+
+		state := registry.Snapshot()
+		assert.Len(t, state.Failures, 50)
+		assert.Len(t, state.Warnings, 50)
+	})
+
+	t.Run("Happy, snapshot and restore", func(t *testing.T) {
+		registry := NewRegistry()
+		_ = registry.Require("REGISTRY_KEY")
+		state := registry.Snapshot()
+
+		_ = registry.Require("REGISTRY_KEY_2")
+		state.Failures[0].Message = "changed"
+		assert.Equal(t, "REGISTRY_KEY env is required.", registry.Snapshot().Failures[0].String())
+
+		registry.Restore(state)
+		assert.Equal(t, "changed", joinIssues(registry.Snapshot().Failures))
+	})
+}
@@ -0,0 +1,119 @@
+// Package envtest provides helpers for testing code reading envs through the
+// env package. The helpers change the process environment and the default
+// env Registry, tests using them must not run in parallel.
+package envtest
+
+import (
+	"gitlab.com/gridwhizth/universe/env"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Setenv sets an env for the duration of the test, the original value is
+// restored by t.Cleanup.
+func Setenv(t testing.TB, name string, value string) {
+	t.Helper()
+	restoreOnCleanup(t, name)
+	if err := os.Setenv(name, value); err != nil {
+		t.Fatalf("envtest: setting %s: %v", name, err)
+	}
+}
+
+// Setenvs sets every env of values like Setenv.
+func Setenvs(t testing.TB, values map[string]string) {
+	t.Helper()
+	for name, value := range values {
+		Setenv(t, name, value)
+	}
+}
+
+// Unsetenv unsets an env for the duration of the test, the original value is
+// restored by t.Cleanup.
+func Unsetenv(t testing.TB, name string) {
+	t.Helper()
+	restoreOnCleanup(t, name)
+	if err := os.Unsetenv(name); err != nil {
+		t.Fatalf("envtest: unsetting %s: %v", name, err)
+	}
+}
+
+// Reset clears the pending issues, inventory, rules and aliases of the
+// default Registry now and again when the test ends.
+func Reset(t testing.TB) {
+	env.Reset()
+	t.Cleanup(env.Reset)
+}
+
+// Failures returns the messages of the pending failures.
+func Failures() []string {
+	return messages(env.Snapshot().Failures)
+}
+
+// Warnings returns the messages of the pending warnings.
+func Warnings() []string {
+	return messages(env.Snapshot().Warnings)
+}
+
+// Message returns the message env.Assert would panic with, empty when there
+// is no pending failure.
+func Message() string {
+	return strings.Join(Failures(), "\n")
+}
+
+// AssertFailures reports an error unless the pending failures have exactly
+// the given messages, in order.
+func AssertFailures(t testing.TB, expected ...string) bool {
+	t.Helper()
+	return assertMessages(t, "failures", Failures(), expected)
+}
+
+// AssertWarnings reports an error unless the pending warnings have exactly
+// the given messages, in order.
+func AssertWarnings(t testing.TB, expected ...string) bool {
+	t.Helper()
+	return assertMessages(t, "warnings", Warnings(), expected)
+}
+
+// AssertMessage reports an error unless env.Assert would panic with expected,
+// or would not panic when expected is empty. Nothing is logged nor cleared.
+func AssertMessage(t testing.TB, expected string) bool {
+	t.Helper()
+	if actual := Message(); actual != expected {
+		t.Errorf("envtest: env.Assert message\nexpected: %q\n  actual: %q", expected, actual)
+		return false
+	}
+	return true
+}
+
+func restoreOnCleanup(t testing.TB, name string) {
+	original, ok := os.LookupEnv(name)
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(name, original)
+		} else {
+			_ = os.Unsetenv(name)
+		}
+	})
+}
+
+func messages(issues []env.Issue) []string {
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.String()
+	}
+	return messages
+}
+
+func assertMessages(t testing.TB, kind string, actual []string, expected []string) bool {
+	t.Helper()
+	if len(actual) == 0 && len(expected) == 0 {
+		return true
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("envtest: pending %s\nexpected: %q\n  actual: %q", kind, expected, actual)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package envtest
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/gridwhizth/universe/env"
+	"os"
+	"testing"
+)
+
+// recorder captures the errors reported through testing.TB.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestSetenv(t *testing.T) {
+	_ = os.Setenv("ENVTEST_EXISTING", "original")
+	defer os.Unsetenv("ENVTEST_EXISTING")
+
+	t.Run("Happy", func(t *testing.T) {
+		Setenv(t, "ENVTEST_EXISTING", "changed")
+		Setenv(t, "ENVTEST_NEW", "new")
+		Setenvs(t, map[string]string{"ENVTEST_MAP": "map"})
+		assert.Equal(t, "changed", env.Require("ENVTEST_EXISTING"))
+		assert.Equal(t, "new", env.Require("ENVTEST_NEW"))
+		assert.Equal(t, "map", env.Require("ENVTEST_MAP"))
+	})
+
+	t.Run("Happy, restored", func(t *testing.T) {
+		assert.Equal(t, "original", os.Getenv("ENVTEST_EXISTING"))
+		_, ok := os.LookupEnv("ENVTEST_NEW")
+		assert.False(t, ok)
+		_, ok = os.LookupEnv("ENVTEST_MAP")
+		assert.False(t, ok)
+	})
+
+	t.Run("Happy, unset", func(t *testing.T) {
+		Unsetenv(t, "ENVTEST_EXISTING")
+		_, ok := os.LookupEnv("ENVTEST_EXISTING")
+		assert.False(t, ok)
+	})
+
+	t.Run("Happy, unset restored", func(t *testing.T) {
+		assert.Equal(t, "original", os.Getenv("ENVTEST_EXISTING"))
+	})
+}
+
+func TestReset(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		env.Require("ENVTEST_LEFTOVER")
+		Reset(t)
+		AssertFailures(t)
+		env.Require("ENVTEST_MISSING")
+		AssertFailures(t, "ENVTEST_MISSING env is required.")
+	})
+
+	t.Run("Happy, reset on cleanup", func(t *testing.T) {
+		assert.Empty(t, Failures())
+	})
+}
+
+func TestAssertions(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		Reset(t)
+		Unsetenv(t, "ENVTEST_REQUIRED")
+		Unsetenv(t, "ENVTEST_PORT")
+		Unsetenv(t, "ENVTEST_OPTIONAL")
+		env.Require("ENVTEST_REQUIRED", "important")
+		env.RequireInt("ENVTEST_PORT")
+		env.WarnIfEmpty("ENVTEST_OPTIONAL")
+
+		assert.True(t, AssertFailures(t, "ENVTEST_REQUIRED env is required. (important)", "ENVTEST_PORT env is required."))
+		assert.True(t, AssertWarnings(t, "ENVTEST_OPTIONAL env is empty, it may be needed."))
+		assert.True(t, AssertMessage(t, "ENVTEST_REQUIRED env is required. (important)\nENVTEST_PORT env is required."))
+		assert.Len(t, Failures(), 2, "assertions must not clear the pending issues")
+	})
+
+	t.Run("Happy, nothing pending", func(t *testing.T) {
+		Reset(t)
+		assert.True(t, AssertFailures(t))
+		assert.True(t, AssertWarnings(t))
+		assert.True(t, AssertMessage(t, ""))
+	})
+
+	t.Run("Unhappy, mismatch", func(t *testing.T) {
+		Reset(t)
+		Unsetenv(t, "ENVTEST_REQUIRED")
+		env.Require("ENVTEST_REQUIRED")
+
+		r := &recorder{TB: t}
+		assert.False(t, AssertFailures(r, "OTHER env is required."))
+		assert.False(t, AssertWarnings(r, "ENVTEST_REQUIRED env is required."))
+		assert.False(t, AssertMessage(r, ""))
+		assert.Len(t, r.errors, 3)
+		assert.Contains(t, r.errors[0], "pending failures")
+		assert.Contains(t, r.errors[2], "env.Assert message")
+	})
+}
@@ -0,0 +1,121 @@
+package env
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var referenceName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// expand replaces the ${VAR}, ${VAR:-default} and ${VAR:?message}
+// references in the value of envName, $${ is kept as a literal ${. A
+// reference resolves like any lookup and falls back to the default declared
+// for it, defaults of secrets excepted.
+func (r *Registry) expand(envName string, res resolution, stack []string) resolution {
+	if !strings.Contains(res.value, "${") {
+		return res
+	}
+	value, issues := r.expandText(envName, res.value, append(stack, envName))
+	res.value = value
+	res.issues = append(res.issues, issues...)
+	return res
+}
+
+func (r *Registry) expandText(envName string, text string, stack []string) (string, []Issue) {
+	var b strings.Builder
+	var issues []Issue
+	for i := 0; i < len(text); {
+		switch {
+		case strings.HasPrefix(text[i:], "$${"):
+			b.WriteString("${")
+			i += 3
+			continue
+		case !strings.HasPrefix(text[i:], "${"):
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		end := closingBrace(text, i+2)
+		if end < 0 {
+			issues = append(issues, failureIssue(envName, KindInvalid, fmt.Sprintf("%s env has an unterminated reference.", envName)))
+			break
+		}
+		value, referenceIssues := r.expandReference(envName, text[i+2:end], stack)
+		b.WriteString(value)
+		issues = append(issues, referenceIssues...)
+		i = end + 1
+	}
+	return b.String(), issues
+}
+
+func (r *Registry) expandReference(envName string, expression string, stack []string) (string, []Issue) {
+	name, operator, argument := expression, "", ""
+	if i := strings.Index(expression, ":"); i >= 0 && len(expression) > i+1 {
+		name, operator, argument = expression[:i], expression[i:i+2], expression[i+2:]
+	}
+	if !referenceName.MatchString(name) || (operator != "" && operator != ":-" && operator != ":?") {
+		return "", []Issue{failureIssue(envName, KindInvalid, fmt.Sprintf("%s env has an invalid reference ${%s}.", envName, expression))}
+	}
+
+	for _, expanding := range stack {
+		if expanding == name {
+			return "", []Issue{failureIssue(envName, KindInvalid, fmt.Sprintf("%s env has a cyclic reference to %s env.", envName, name))}
+		}
+	}
+
+	value, found, issues := r.referenceValue(name, stack)
+	if found && value != "" {
+		return value, issues
+	}
+	switch operator {
+	case ":-":
+		defaultValue, defaultIssues := r.expandText(envName, argument, stack)
+		return defaultValue, append(issues, defaultIssues...)
+	case ":?":
+		message := fmt.Sprintf("%s env requires %s env.", envName, name)
+		if argument != "" {
+			message = fmt.Sprintf("%s env requires %s env, %s.", envName, name, argument)
+		}
+		return "", append(issues, failureIssue(envName, KindInvalid, message))
+	}
+	if !found {
+		issues = append(issues, failureIssue(envName, KindInvalid, fmt.Sprintf("%s env references undefined %s env.", envName, name)))
+	}
+	return value, issues
+}
+
+func (r *Registry) referenceValue(name string, stack []string) (string, bool, []Issue) {
+	res := r.resolveExpanding(name, stack)
+	if res.found {
+		return res.value, true, res.issues
+	}
+
+	r.mu.Lock()
+	v, declared := r.variables[name]
+	r.mu.Unlock()
+	if declared && v.HasDefault && !v.Secret {
+		return v.Default, true, res.issues
+	}
+	return "", false, res.issues
+}
+
+// closingBrace returns the index of the brace closing the reference opened
+// before start, references nested in a default are skipped.
+func closingBrace(text string, start int) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], "${"):
+			depth++
+			i++
+		case text[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
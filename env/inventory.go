@@ -0,0 +1,169 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Variable describes an env declared through a lookup, Default is redacted
+// for secrets.
+type Variable struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	HasDefault  bool   `json:"-"`
+	Default     string `json:"default,omitempty"`
+	Secret      bool   `json:"secret,omitempty"`
+	Set         bool   `json:"set"`
+	Source      string `json:"source,omitempty"`
+}
+
+func requiredVariable(envName string, description []string) Variable {
+	v := optionalVariable(envName, description)
+	v.Required = true
+	return v
+}
+
+func optionalVariable(envName string, description []string) Variable {
+	v := Variable{Name: envName}
+	if len(description) > 0 {
+		v.Description = description[0]
+	}
+	return v
+}
+
+func defaultVariable(envName string, defaultValue string) Variable {
+	return Variable{Name: envName, HasDefault: true, Default: defaultValue}
+}
+
+func formatDefault(p parser, defaultValue interface{}) string {
+	if p.format != nil {
+		if reflect.ValueOf(defaultValue).IsNil() {
+			return ""
+		}
+		return p.format(defaultValue)
+	}
+	switch value := defaultValue.(type) {
+	case *url.URL:
+		if value == nil {
+			return ""
+		}
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func Inventory() []Variable {
+	return defaultRegistry.Inventory()
+}
+
+func WriteMarkdown(w io.Writer) error {
+	return defaultRegistry.WriteMarkdown(w)
+}
+
+func WriteJSON(w io.Writer) error {
+	return defaultRegistry.WriteJSON(w)
+}
+
+func WriteDotenvExample(w io.Writer) error {
+	return defaultRegistry.WriteDotenvExample(w)
+}
+
+// Inventory returns every env declared so far in declaration order. A
+// variable declared several times keeps the latest declaration.
+func (r *Registry) Inventory() []Variable {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	variables := make([]Variable, len(r.variableNames))
+	for i, name := range r.variableNames {
+		variables[i] = r.variables[name]
+	}
+	return variables
+}
+
+func (r *Registry) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("| Name | Description | Required | Default | Set | Source |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, v := range r.Inventory() {
+		defaultValue := ""
+		if v.HasDefault {
+			defaultValue = "`" + v.Default + "`"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s | %s |\n",
+			v.Name, markdownCell(v.Description), yesNo(v.Required), markdownCell(defaultValue), yesNo(v.Set), markdownCell(v.Source))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (r *Registry) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r.Inventory())
+}
+
+// WriteDotenvExample writes a dotenv file declaring every env with its
+// default value, secrets are always left empty.
+func (r *Registry) WriteDotenvExample(w io.Writer) error {
+	var b strings.Builder
+	for i, v := range r.Inventory() {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		comment := v.Description
+		if v.Required {
+			comment = strings.TrimSpace(comment + " (required)")
+		}
+		if comment != "" {
+			fmt.Fprintf(&b, "# %s\n", strings.Replace(comment, "\n", " ", -1))
+		}
+		value := ""
+		if v.HasDefault && !v.Secret {
+			value = dotenvValue(v.Default)
+		}
+		fmt.Fprintf(&b, "%s=%s\n", v.Name, value)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (r *Registry) declare(v Variable) {
+	if v.Secret && v.HasDefault {
+		v.Default = redacted
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.variables == nil {
+		r.variables = map[string]Variable{}
+	}
+	if _, ok := r.variables[v.Name]; !ok {
+		r.variableNames = append(r.variableNames, v.Name)
+	}
+	r.variables[v.Name] = v
+}
+
+func markdownCell(s string) string {
+	return strings.Replace(strings.Replace(s, "|", "\\|", -1), "\n", " ", -1)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func dotenvValue(value string) string {
+	if !strings.ContainsAny(value, " \t\n\"'#\\$") {
+		return value
+	}
+	replacer := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\t", "\\t", "$", "\\$")
+	return `"` + replacer.Replace(value) + `"`
+}
@@ -0,0 +1,115 @@
+package env
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestInventory(t *testing.T) {
+	init := func() *Registry {
+		_ = os.Setenv("INVENTORY_URL", "https://example.com")
+		registry := NewRegistry()
+		_ = registry.Require("INVENTORY_URL", "simple url")
+		_ = registry.WarnIfEmpty("INVENTORY_NAME", "service | name")
+		_ = registry.DefaultDuration("INVENTORY_TIMEOUT", 5*time.Second)
+		_ = registry.Default("INVENTORY_GREETING", "hello world")
+		_ = registry.DefaultSecret("INVENTORY_TOKEN", "dev-token")
+		return registry
+	}
+	reset := func() {
+		_ = os.Unsetenv("INVENTORY_URL")
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		registry := init()
+		defer reset()
+
+		assert.Equal(t, []Variable{
+			{Name: "INVENTORY_URL", Description: "simple url", Required: true, Set: true, Source: ProcessSource},
+			{Name: "INVENTORY_NAME", Description: "service | name"},
+			{Name: "INVENTORY_TIMEOUT", HasDefault: true, Default: "5s"},
+			{Name: "INVENTORY_GREETING", HasDefault: true, Default: "hello world"},
+			{Name: "INVENTORY_TOKEN", HasDefault: true, Default: "[REDACTED]", Secret: true},
+		}, registry.Inventory())
+
+		registry.Reset()
+		assert.Empty(t, registry.Inventory())
+	})
+
+	t.Run("Happy, declared twice keeps the latest", func(t *testing.T) {
+		registry := NewRegistry()
+		_ = registry.WarnIfEmpty("INVENTORY_NAME")
+		_ = registry.Require("INVENTORY_NAME", "service name")
+
+		inventory := registry.Inventory()
+		assert.Len(t, inventory, 1)
+		assert.True(t, inventory[0].Required)
+	})
+
+	t.Run("Happy, markdown", func(t *testing.T) {
+		registry := init()
+		defer reset()
+
+		var buffer bytes.Buffer
+		assert.Nil(t, registry.WriteMarkdown(&buffer))
+		assert.Equal(t, "| Name | Description | Required | Default | Set | Source |\n"+
+			"| --- | --- | --- | --- | --- | --- |\n"+
+			"| `INVENTORY_URL` | simple url | yes |  | yes | process environment |\n"+
+			"| `INVENTORY_NAME` | service \\| name | no |  | no |  |\n"+
+			"| `INVENTORY_TIMEOUT` |  | no | `5s` | no |  |\n"+
+			"| `INVENTORY_GREETING` |  | no | `hello world` | no |  |\n"+
+			"| `INVENTORY_TOKEN` |  | no | `[REDACTED]` | no |  |\n", buffer.String())
+	})
+
+	t.Run("Happy, json", func(t *testing.T) {
+		registry := NewRegistry()
+		_ = registry.Require("INVENTORY_KEY", "important key")
+		_ = registry.DefaultSecret("INVENTORY_TOKEN", "dev-token")
+
+		var buffer bytes.Buffer
+		assert.Nil(t, registry.WriteJSON(&buffer))
+		assert.JSONEq(t, `[
+			{"name": "INVENTORY_KEY", "description": "important key", "required": true, "set": false},
+			{"name": "INVENTORY_TOKEN", "required": false, "default": "[REDACTED]", "secret": true, "set": false}
+		]`, buffer.String())
+		assert.NotContains(t, buffer.String(), "dev-token")
+	})
+
+	t.Run("Happy, dotenv example", func(t *testing.T) {
+		registry := init()
+		defer reset()
+
+		var buffer bytes.Buffer
+		assert.Nil(t, registry.WriteDotenvExample(&buffer))
+		assert.Equal(t, "# simple url (required)\nINVENTORY_URL=\n\n"+
+			"# service | name\nINVENTORY_NAME=\n\n"+
+			"INVENTORY_TIMEOUT=5s\n\n"+
+			"INVENTORY_GREETING=\"hello world\"\n\n"+
+			"INVENTORY_TOKEN=\n", buffer.String())
+
+		values, err := parseDotenv(buffer.String())
+		assert.Nil(t, err)
+		assert.Equal(t, "hello world", values["INVENTORY_GREETING"])
+	})
+	t.Run("Happy, nil list and map defaults", func(t *testing.T) {
+		registry := NewRegistry(Map("test", nil))
+		_ = registry.DefaultList("INVENTORY_ORIGINS", ",", nil)
+		_ = registry.DefaultMap("INVENTORY_FEATURES", ";", "=", nil)
+		_ = registry.DefaultList("INVENTORY_HOSTS", ",", []string{"a", "b"})
+
+		inventory := registry.Inventory()
+		assert.Equal(t, "", inventory[0].Default)
+		assert.Equal(t, "", inventory[1].Default)
+		assert.Equal(t, "a,b", inventory[2].Default)
+
+		var buffer bytes.Buffer
+		assert.Nil(t, registry.WriteDotenvExample(&buffer))
+		values, err := parseDotenv(buffer.String())
+		assert.Nil(t, err)
+		assert.Equal(t, "", values["INVENTORY_ORIGINS"])
+		assert.Equal(t, "", values["INVENTORY_FEATURES"])
+	})
+}
@@ -0,0 +1,145 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityFailure
+)
+
+func (s Severity) String() string {
+	if s == SeverityFailure {
+		return "failure"
+	}
+	return "warning"
+}
+
+type Kind int
+
+const (
+	KindMissing Kind = iota
+	KindEmpty
+	KindInvalid
+	KindConfiguration
+	KindDeprecated
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindMissing:
+		return "missing"
+	case KindEmpty:
+		return "empty"
+	case KindInvalid:
+		return "invalid"
+	case KindDeprecated:
+		return "deprecated"
+	default:
+		return "configuration"
+	}
+}
+
+// Issue is a single problem found while looking up an env, Message is
+// rendered without the description. Source tells where an invalid value was
+// read from.
+type Issue struct {
+	Name        string
+	Description string
+	Source      string
+	Kind        Kind
+	Severity    Severity
+	Message     string
+}
+
+func (i Issue) String() string {
+	if i.Description == "" {
+		return i.Message
+	}
+	return prependDescription(i.Message, []string{i.Description})
+}
+
+// Fields returns the structured fields of i for a Logger, empty fields are
+// left out.
+func (i Issue) Fields() map[string]string {
+	fields := map[string]string{
+		"kind":     i.Kind.String(),
+		"severity": i.Severity.String(),
+	}
+	for key, value := range map[string]string{"env": i.Name, "description": i.Description, "source": i.Source} {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// AssertionError is returned by AssertErr when at least one failure is
+// pending, Issues holds the warnings as well.
+type AssertionError struct {
+	Issues []Issue
+}
+
+func (e *AssertionError) Error() string {
+	return joinIssues(e.Failures())
+}
+
+func (e *AssertionError) Failures() []Issue {
+	return e.filter(SeverityFailure)
+}
+
+func (e *AssertionError) Warnings() []Issue {
+	return e.filter(SeverityWarning)
+}
+
+func (e *AssertionError) filter(severity Severity) []Issue {
+	var issues []Issue
+	for _, issue := range e.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func requiredIssue(envName string, description []string) Issue {
+	return newIssue(envName, description, KindMissing, fmt.Sprintf("%s env is required.", envName))
+}
+
+func emptyIssue(envName string, description []string) Issue {
+	return newIssue(envName, description, KindEmpty, fmt.Sprintf("%s env is empty, it may be needed.", envName))
+}
+
+func invalidIssue(envName string, kind string, description []string) Issue {
+	return newIssue(envName, description, KindInvalid, fmt.Sprintf("%s env must be %s.", envName, kind))
+}
+
+func configurationIssue(envName string, message string) Issue {
+	return newIssue(envName, nil, KindConfiguration, message)
+}
+
+func failureIssue(envName string, kind Kind, message string) Issue {
+	issue := newIssue(envName, nil, kind, message)
+	issue.Severity = SeverityFailure
+	return issue
+}
+
+func newIssue(envName string, description []string, kind Kind, message string) Issue {
+	issue := Issue{Name: envName, Kind: kind, Message: message}
+	if len(description) > 0 {
+		issue.Description = description[0]
+	}
+	return issue
+}
+
+func joinIssues(issues []Issue) string {
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.String()
+	}
+	return strings.Join(messages, "\n")
+}
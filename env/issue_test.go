@@ -0,0 +1,56 @@
+package env
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestAssertErr(t *testing.T) {
+	var buffer bytes.Buffer
+	log.SetOutput(&buffer)
+	defer log.SetOutput(os.Stderr)
+
+	t.Run("Happy", func(t *testing.T) {
+		registry := NewRegistry()
+		_ = registry.WarnIfEmpty("ASSERT_ERR_KEY", "optional key")
+
+		assert.Nil(t, registry.AssertErr())
+		assert.Contains(t, buffer.String(), "ASSERT_ERR_KEY env is empty, it may be needed. (optional key)")
+		assert.Empty(t, registry.Snapshot().Warnings)
+		buffer.Reset()
+	})
+
+	t.Run("Unhappy, issues are returned", func(t *testing.T) {
+		_ = os.Setenv("ASSERT_ERR_INVALID_KEY", "abc")
+		defer os.Unsetenv("ASSERT_ERR_INVALID_KEY")
+
+		registry := NewRegistry()
+		_ = registry.Require("ASSERT_ERR_KEY", "important key")
+		_ = registry.WarnIfEmpty("ASSERT_ERR_KEY_2")
+		_ = registry.RequireInt("ASSERT_ERR_INVALID_KEY")
+
+		err := registry.AssertErr()
+		var assertionError *AssertionError
+		assert.True(t, errors.As(err, &assertionError))
+		assert.Equal(t, "ASSERT_ERR_KEY env is required. (important key)\nASSERT_ERR_INVALID_KEY env must be an integer.", err.Error())
+
+		failures := assertionError.Failures()
+		assert.Len(t, failures, 2)
+		assert.Equal(t, "ASSERT_ERR_KEY", failures[0].Name)
+		assert.Equal(t, "important key", failures[0].Description)
+		assert.Equal(t, KindMissing, failures[0].Kind)
+		assert.Equal(t, SeverityFailure, failures[0].Severity)
+		assert.Equal(t, KindInvalid, failures[1].Kind)
+
+		warnings := assertionError.Warnings()
+		assert.Len(t, warnings, 1)
+		assert.Equal(t, "ASSERT_ERR_KEY_2", warnings[0].Name)
+		assert.Equal(t, KindEmpty, warnings[0].Kind)
+		assert.Equal(t, "warning", warnings[0].Severity.String())
+		buffer.Reset()
+	})
+}
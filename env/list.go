@@ -0,0 +1,196 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// elementError reports which element of a list or map env is invalid.
+type elementError struct {
+	element string
+	expect  string
+}
+
+func (e *elementError) Error() string {
+	return fmt.Sprintf("element %s must be %s", e.element, e.expect)
+}
+
+func RequireList(envName string, separator string, description ...string) []string {
+	return defaultRegistry.RequireList(envName, separator, description...)
+}
+
+func (r *Registry) RequireList(envName string, separator string, description ...string) []string {
+	return r.requireParsed(envName, listParser(stringParser, separator), description).([]string)
+}
+
+func WarnIfEmptyList(envName string, separator string, description ...string) []string {
+	return defaultRegistry.WarnIfEmptyList(envName, separator, description...)
+}
+
+func (r *Registry) WarnIfEmptyList(envName string, separator string, description ...string) []string {
+	return r.warnIfEmptyParsed(envName, listParser(stringParser, separator), description).([]string)
+}
+
+func DefaultList(envName string, separator string, defaultValue []string) []string {
+	return defaultRegistry.DefaultList(envName, separator, defaultValue)
+}
+
+func (r *Registry) DefaultList(envName string, separator string, defaultValue []string) []string {
+	return r.defaultParsed(envName, listParser(stringParser, separator), defaultValue, nil).([]string)
+}
+
+func RequireMap(envName string, separator string, kvSeparator string, description ...string) map[string]string {
+	return defaultRegistry.RequireMap(envName, separator, kvSeparator, description...)
+}
+
+func (r *Registry) RequireMap(envName string, separator string, kvSeparator string, description ...string) map[string]string {
+	return r.requireParsed(envName, mapParser(stringParser, separator, kvSeparator), description).(map[string]string)
+}
+
+func WarnIfEmptyMap(envName string, separator string, kvSeparator string, description ...string) map[string]string {
+	return defaultRegistry.WarnIfEmptyMap(envName, separator, kvSeparator, description...)
+}
+
+func (r *Registry) WarnIfEmptyMap(envName string, separator string, kvSeparator string, description ...string) map[string]string {
+	return r.warnIfEmptyParsed(envName, mapParser(stringParser, separator, kvSeparator), description).(map[string]string)
+}
+
+func DefaultMap(envName string, separator string, kvSeparator string, defaultValue map[string]string) map[string]string {
+	return defaultRegistry.DefaultMap(envName, separator, kvSeparator, defaultValue)
+}
+
+func (r *Registry) DefaultMap(envName string, separator string, kvSeparator string, defaultValue map[string]string) map[string]string {
+	return r.defaultParsed(envName, mapParser(stringParser, separator, kvSeparator), defaultValue, nil).(map[string]string)
+}
+
+// listParser parses a separated list whose elements are parsed by element,
+// the zero value is a nil slice of the element type.
+func listParser(element parser, separator string) parser {
+	sliceType := reflect.SliceOf(reflect.TypeOf(element.zero))
+	return parser{
+		kind: fmt.Sprintf("a list separated by %q", separator),
+		zero: reflect.Zero(sliceType).Interface(),
+		parse: func(env string) (interface{}, error) {
+			elements, err := splitList(env, separator)
+			if err != nil {
+				return nil, err
+			}
+			list := reflect.MakeSlice(sliceType, 0, len(elements))
+			for i, e := range elements {
+				value, err := element.parse(e)
+				if err != nil {
+					return nil, &elementError{element: strconv.Itoa(i + 1), expect: element.kind}
+				}
+				list = reflect.Append(list, reflect.ValueOf(value))
+			}
+			return list.Interface(), nil
+		},
+		format: func(value interface{}) string {
+			list := reflect.ValueOf(value)
+			elements := make([]string, list.Len())
+			for i := range elements {
+				elements[i] = formatDefault(element, list.Index(i).Interface())
+			}
+			return strings.Join(elements, separator)
+		},
+	}
+}
+
+// mapParser parses a separated list of key/value pairs, a pair is split on
+// the first kvSeparator so only the keys cannot contain it.
+func mapParser(element parser, separator string, kvSeparator string) parser {
+	mapType := reflect.MapOf(stringType, reflect.TypeOf(element.zero))
+	return parser{
+		kind: fmt.Sprintf("a map of key%svalue pairs separated by %q", kvSeparator, separator),
+		zero: reflect.Zero(mapType).Interface(),
+		parse: func(env string) (interface{}, error) {
+			pairs, err := splitList(env, separator)
+			if err != nil {
+				return nil, err
+			}
+			m := reflect.MakeMapWithSize(mapType, len(pairs))
+			for i, pair := range pairs {
+				kv := strings.SplitN(pair, kvSeparator, 2)
+				if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+					return nil, &elementError{element: strconv.Itoa(i + 1), expect: fmt.Sprintf("a key%svalue pair", kvSeparator)}
+				}
+				key := strings.TrimSpace(kv[0])
+				value, err := element.parse(strings.TrimSpace(kv[1]))
+				if err != nil {
+					return nil, &elementError{element: strconv.Quote(key), expect: element.kind}
+				}
+				m.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(value))
+			}
+			return m.Interface(), nil
+		},
+		format: func(value interface{}) string {
+			m := reflect.ValueOf(value)
+			pairs := make([]string, 0, m.Len())
+			for _, key := range m.MapKeys() {
+				pairs = append(pairs, key.String()+kvSeparator+formatDefault(element, m.MapIndex(key).Interface()))
+			}
+			sort.Strings(pairs)
+			return strings.Join(pairs, separator)
+		},
+	}
+}
+
+// splitList splits value on separator. Elements are trimmed, double quotes
+// and a backslash keep a separator or surrounding spaces inside an element,
+// and empty unquoted elements are dropped.
+func splitList(value string, separator string) ([]string, error) {
+	var elements []string
+	var current strings.Builder
+	quoted := false
+	protectedStart, protectedEnd := -1, -1
+
+	protect := func() {
+		if protectedStart < 0 {
+			protectedStart = current.Len()
+		}
+	}
+	flush := func() {
+		element := current.String()
+		if protectedStart < 0 {
+			if element = strings.TrimSpace(element); element != "" {
+				elements = append(elements, element)
+			}
+		} else {
+			element = strings.TrimLeft(element[:protectedStart], " \t") + element[protectedStart:protectedEnd] + strings.TrimRight(element[protectedEnd:], " \t")
+			elements = append(elements, element)
+		}
+		current.Reset()
+		protectedStart, protectedEnd = -1, -1
+	}
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\' && i+1 < len(value):
+			protect()
+			i++
+			current.WriteByte(value[i])
+			protectedEnd = current.Len()
+		case c == '"':
+			protect()
+			quoted = !quoted
+			protectedEnd = current.Len()
+		case quoted:
+			current.WriteByte(c)
+			protectedEnd = current.Len()
+		case separator != "" && strings.HasPrefix(value[i:], separator):
+			flush()
+			i += len(separator) - 1
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+	return elements, nil
+}
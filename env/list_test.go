@@ -0,0 +1,110 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSplitList(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		cases := map[string][]string{
+			"a,b,c":              {"a", "b", "c"},
+			" a , b ,, c, ":      {"a", "b", "c"},
+			`"a, b" , c`:         {"a, b", "c"},
+			`a\,b,c`:             {"a,b", "c"},
+			`" padded ",x`:       {" padded ", "x"},
+			`"",x`:               {"", "x"},
+			"":                   nil,
+			`say "hi, you",then`: {"say hi, you", "then"},
+		}
+		for value, expected := range cases {
+			elements, err := splitList(value, ",")
+			assert.Nil(t, err, value)
+			assert.Equal(t, expected, elements, value)
+		}
+
+		elements, err := splitList("k1=v1;;k2=v2", ";;")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"k1=v1", "k2=v2"}, elements)
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		_, err := splitList(`"a,b`, ",")
+		assert.EqualError(t, err, "unterminated quote")
+	})
+}
+
+func TestRequireList(t *testing.T) {
+	registry := func() *Registry {
+		return NewRegistry(Map("test", map[string]string{
+			"LIST_ORIGINS":  "https://a.example, https://b.example",
+			"LIST_BROKERS":  "kafka-1:9092 kafka-2:9092",
+			"LIST_FEATURES": "search=on; export = off;quote=\"a;b\"",
+			"LIST_BROKEN":   `"a,b`,
+			"LIST_PAIRS":    "a=1,b",
+		}))
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		r := registry()
+		assert.Equal(t, []string{"https://a.example", "https://b.example"}, r.RequireList("LIST_ORIGINS", ","))
+		assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, r.WarnIfEmptyList("LIST_BROKERS", " "))
+		assert.Equal(t, []string{"x"}, r.DefaultList("LIST_MISSING", ",", []string{"x"}))
+		assert.Equal(t, map[string]string{"search": "on", "export": "off", "quote": "a;b"}, r.RequireMap("LIST_FEATURES", ";", "="))
+		assert.Equal(t, map[string]string{"k": "v"}, r.DefaultMap("LIST_MISSING", ";", "=", map[string]string{"k": "v"}))
+		assert.Empty(t, r.Snapshot().Failures)
+		assert.Equal(t, "k=v", r.Inventory()[2].Default)
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		r := registry()
+		assert.Nil(t, r.RequireList("LIST_BROKEN", ","))
+		assert.Nil(t, r.RequireMap("LIST_PAIRS", ",", "="))
+		assert.Nil(t, r.RequireList("LIST_MISSING", ",", "allowed origins"))
+		assert.Nil(t, r.WarnIfEmptyMap("LIST_MISSING_MAP", ",", "="))
+
+		assert.Equal(t, `LIST_BROKEN env must be a list separated by ",".`+"\n"+
+			"LIST_PAIRS env element 2 must be a key=value pair.\n"+
+			"LIST_MISSING env is required. (allowed origins)", joinIssues(r.Snapshot().Failures))
+		assert.Equal(t, "LIST_MISSING_MAP env is empty, it may be needed.", joinIssues(r.Snapshot().Warnings))
+	})
+}
+
+func TestLoadList(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		r := NewRegistry(Map("test", map[string]string{
+			"LOAD_LIST_PORTS":    "8080, 8081",
+			"LOAD_LIST_TIMEOUTS": "read=1s;write=2s",
+		}))
+		var config struct {
+			Ports    []int                    `env:"LOAD_LIST_PORTS,required"`
+			Timeouts map[string]time.Duration `env:"LOAD_LIST_TIMEOUTS" sep:";"`
+			Hosts    []string                 `env:"LOAD_LIST_HOSTS" default:"a|b" sep:"|"`
+		}
+		r.Load(&config)
+
+		assert.Equal(t, []int{8080, 8081}, config.Ports)
+		assert.Equal(t, map[string]time.Duration{"read": time.Second, "write": 2 * time.Second}, config.Timeouts)
+		assert.Equal(t, []string{"a", "b"}, config.Hosts)
+		assert.Empty(t, r.Snapshot().Failures)
+		assert.Equal(t, "a|b", r.Inventory()[2].Default)
+	})
+
+	t.Run("Unhappy, element level errors", func(t *testing.T) {
+		r := NewRegistry(Map("test", map[string]string{
+			"LOAD_LIST_PORTS":    "8080,http",
+			"LOAD_LIST_TIMEOUTS": "read=1s,write=later",
+		}))
+		var config struct {
+			Ports    []int                    `env:"LOAD_LIST_PORTS,required" desc:"listening ports"`
+			Timeouts map[string]time.Duration `env:"LOAD_LIST_TIMEOUTS"`
+			Channels map[int]string           `env:"LOAD_LIST_CHANNELS"`
+		}
+		r.Load(&config)
+
+		assert.Equal(t, "LOAD_LIST_PORTS env element 2 must be an integer. (listening ports)\n"+
+			`LOAD_LIST_TIMEOUTS env element "write" must be a duration.`+"\n"+
+			"LOAD_LIST_CHANNELS env cannot be loaded into field Channels of unsupported type map[int]string.", joinIssues(r.Snapshot().Failures))
+	})
+}
@@ -0,0 +1,199 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var stringParser = parser{kind: "a string", zero: "", parse: func(env string) (interface{}, error) {
+	return env, nil
+}}
+
+var (
+	fieldParsers = map[reflect.Type]parser{}
+	stringType   = reflect.TypeOf("")
+)
+
+func init() {
+	for _, p := range []parser{stringParser, intParser, int64Parser, boolParser, float64Parser, durationParser, decimalParser, urlParser, secretParser} {
+		fieldParsers[reflect.TypeOf(p.zero)] = p
+	}
+}
+
+// Load fills the fields of the struct pointed to by config from the env
+// named in their `env` tag. A field tagged `env:"NAME,required"` behaves like
+// Require, or only in the listed profiles with `env:"NAME,required=staging|production"`,
+// a field with a `default` tag like Default and any other like WarnIfEmpty,
+// the `desc` tag is used as description. A default is used outside the
+// profiles a field is required in, it conflicts with a plain required. Untagged
+// struct fields, or pointers to structs, are loaded recursively with their
+// `envPrefix` tag prepended to the names when they have an `envPrefix` tag or
+// `env` tagged fields, a nil pointer is then allocated. Problems are queued
+// for Assert like every other lookup.
+func Load(config interface{}) {
+	defaultRegistry.Load(config)
+}
+
+func (r *Registry) Load(config interface{}) {
+	v := reflect.ValueOf(config)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		r.prePanic(configurationIssue("", fmt.Sprintf("env.Load requires a pointer to a struct, got %T.", config)))
+		return
+	}
+	r.loadStruct(v.Elem(), "", map[reflect.Type]bool{})
+}
+
+// loadStruct loads the fields of v, loading is the set of the struct types
+// being loaded to stop on recursive types.
+func (r *Registry) loadStruct(v reflect.Value, prefix string, loading map[reflect.Type]bool) {
+	t := v.Type()
+	loading[t] = true
+	defer delete(loading, t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup("env")
+		if !tagged {
+			r.loadNested(v.Field(i), field, prefix, loading)
+			continue
+		}
+		r.loadField(v.Field(i), field, prefix, tag)
+	}
+}
+
+// parserFor returns the parser of a supported field type, slices and maps
+// with string keys of a supported type are split on the `sep` and `kvsep`
+// tags.
+func parserFor(field reflect.StructField) (parser, bool) {
+	if p, ok := fieldParsers[field.Type]; ok {
+		return p, true
+	}
+
+	separator, kvSeparator := ",", "="
+	if sep, ok := field.Tag.Lookup("sep"); ok {
+		separator = sep
+	}
+	if kvsep, ok := field.Tag.Lookup("kvsep"); ok {
+		kvSeparator = kvsep
+	}
+	switch field.Type.Kind() {
+	case reflect.Slice:
+		if element, ok := fieldParsers[field.Type.Elem()]; ok {
+			return listParser(element, separator), true
+		}
+	case reflect.Map:
+		if element, ok := fieldParsers[field.Type.Elem()]; ok && field.Type.Key() == stringType {
+			return mapParser(element, separator, kvSeparator), true
+		}
+	}
+	return parser{}, false
+}
+
+func (r *Registry) loadNested(v reflect.Value, field reflect.StructField, prefix string, loading map[reflect.Type]bool) {
+	if _, ok := fieldParsers[field.Type]; ok {
+		return
+	}
+	structType := field.Type
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct || loading[structType] {
+		return
+	}
+	envPrefix, hasPrefix := field.Tag.Lookup("envPrefix")
+	if !hasPrefix && !hasEnvTags(structType, map[reflect.Type]bool{}) {
+		return
+	}
+
+	prefix = prefix + envPrefix
+	if field.Type.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(structType))
+		}
+		v = v.Elem()
+	}
+	r.loadStruct(v, prefix, loading)
+}
+
+// hasEnvTags reports whether a field of t, or of the structs it nests, has
+// an `env` tag.
+func hasEnvTags(t reflect.Type, seen map[reflect.Type]bool) bool {
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("env"); ok {
+			return true
+		}
+		nested := field.Type
+		if nested.Kind() == reflect.Ptr {
+			nested = nested.Elem()
+		}
+		if _, ok := fieldParsers[field.Type]; !ok && nested.Kind() == reflect.Struct && !seen[nested] && hasEnvTags(nested, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Registry) loadField(v reflect.Value, field reflect.StructField, prefix string, tag string) {
+	options := strings.Split(tag, ",")
+	envName := prefix + strings.TrimSpace(options[0])
+	fullName := r.name(envName)
+	if strings.TrimSpace(options[0]) == "" {
+		r.prePanic(configurationIssue("", fmt.Sprintf("%s field has an empty env name.", field.Name)))
+		return
+	}
+
+	required, alwaysRequired := false, false
+	for _, option := range options[1:] {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == "required":
+			required, alwaysRequired = true, true
+		case strings.HasPrefix(option, "required="):
+			required = r.IsProfile(strings.Split(strings.TrimPrefix(option, "required="), "|")...)
+		default:
+			r.prePanic(configurationIssue(fullName, fmt.Sprintf("%s env has an unknown option %q on field %s.", fullName, option, field.Name)))
+			return
+		}
+	}
+
+	p, ok := parserFor(field)
+	if !ok {
+		r.prePanic(configurationIssue(fullName, fmt.Sprintf("%s env cannot be loaded into field %s of unsupported type %s.", fullName, field.Name, field.Type)))
+		return
+	}
+
+	var description []string
+	if desc, ok := field.Tag.Lookup("desc"); ok {
+		description = []string{desc}
+	}
+
+	var value interface{}
+	defaultTag, hasDefault := field.Tag.Lookup("default")
+	if alwaysRequired && hasDefault {
+		r.prePanic(configurationIssue(fullName, fmt.Sprintf("%s env is required and has a default on field %s, use required=<profiles> to require it only in some profiles.", fullName, field.Name)))
+		return
+	}
+	switch {
+	case required:
+		value = r.requireParsed(envName, p, description)
+	case hasDefault:
+		defaultValue, err := p.parse(defaultTag)
+		if err != nil {
+			r.prePanic(configurationIssue(fullName, fmt.Sprintf("%s env has an invalid default on field %s, must be %s.", fullName, field.Name, p.kind)))
+			return
+		}
+		value = r.defaultParsed(envName, p, defaultValue, description)
+	default:
+		value = r.warnIfEmptyParsed(envName, p, description)
+	}
+	v.Set(reflect.ValueOf(value))
+}
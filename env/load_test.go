@@ -0,0 +1,138 @@
+package env
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+type databaseConfig struct {
+	Host string `env:"HOST,required" desc:"database host"`
+	Port int    `env:"PORT" default:"5432"`
+}
+
+type loadConfig struct {
+	Name     string          `env:"LOAD_NAME,required" desc:"service name"`
+	Port     int             `env:"LOAD_PORT" default:"8080" desc:"listening port"`
+	Debug    bool            `env:"LOAD_DEBUG" default:"false"`
+	Timeout  time.Duration   `env:"LOAD_TIMEOUT" default:"5s"`
+	Fee      decimal.Decimal `env:"LOAD_FEE" default:"0.5"`
+	Callback *url.URL        `env:"LOAD_CALLBACK"`
+	Database databaseConfig  `envPrefix:"LOAD_DB_"`
+	Replica  *databaseConfig `envPrefix:"LOAD_REPLICA_"`
+	ignored  string
+}
+
+type untaggedNode struct {
+	Value string
+	Next  *untaggedNode
+}
+
+type loadNode struct {
+	Name string    `env:"LOAD_NODE_NAME"`
+	Next *loadNode `envPrefix:"NEXT_"`
+}
+
+func TestLoad(t *testing.T) {
+	init := func() {
+		Reset()
+		_ = os.Setenv("LOAD_NAME", "universe")
+		_ = os.Setenv("LOAD_PORT", "9090")
+		_ = os.Setenv("LOAD_CALLBACK", "https://example.com/callback")
+		_ = os.Setenv("LOAD_DB_HOST", "db.local")
+		_ = os.Setenv("LOAD_REPLICA_HOST", "replica.local")
+		_ = os.Setenv("LOAD_REPLICA_PORT", "6432")
+	}
+	reset := func() {
+		for _, key := range []string{"LOAD_NAME", "LOAD_PORT", "LOAD_CALLBACK", "LOAD_DB_HOST", "LOAD_REPLICA_HOST", "LOAD_REPLICA_PORT"} {
+			_ = os.Unsetenv(key)
+		}
+		Reset()
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		init()
+		var config loadConfig
+		Load(&config)
+
+		assert.Equal(t, "universe", config.Name)
+		assert.Equal(t, 9090, config.Port)
+		assert.Equal(t, false, config.Debug)
+		assert.Equal(t, 5*time.Second, config.Timeout)
+		assert.True(t, decimal.RequireFromString("0.5").Equal(config.Fee))
+		assert.Equal(t, "example.com", config.Callback.Host)
+		assert.Equal(t, "db.local", config.Database.Host)
+		assert.Equal(t, 5432, config.Database.Port)
+		assert.Equal(t, "replica.local", config.Replica.Host)
+		assert.Equal(t, 6432, config.Replica.Port)
+		assert.Len(t, defaultRegistry.failures, 0)
+		assert.Len(t, defaultRegistry.warnings, 0)
+		reset()
+	})
+
+	t.Run("Unhappy, env not defined or malformed", func(t *testing.T) {
+		init()
+		_ = os.Unsetenv("LOAD_NAME")
+		_ = os.Unsetenv("LOAD_CALLBACK")
+		_ = os.Setenv("LOAD_PORT", "http")
+		var config loadConfig
+		Load(&config)
+
+		assert.Equal(t, 8080, config.Port)
+		assert.Equal(t, defaultRegistry.failures[0].String(), "LOAD_NAME env is required. (service name)")
+		assert.Equal(t, defaultRegistry.failures[1].String(), "LOAD_PORT env must be an integer. (listening port)")
+		assert.Equal(t, defaultRegistry.warnings[0].String(), "LOAD_CALLBACK env is empty, it may be needed.")
+		reset()
+	})
+
+	t.Run("Unhappy, unsupported configuration", func(t *testing.T) {
+		init()
+		var config struct {
+			Channel chan int `env:"LOAD_CHANNEL"`
+			Port    int      `env:"LOAD_PORT" default:"eighty"`
+			Name    string   `env:"LOAD_NAME,optional"`
+		}
+		Load(&config)
+		Load(config)
+
+		assert.Equal(t, defaultRegistry.failures[0].String(), "LOAD_CHANNEL env cannot be loaded into field Channel of unsupported type chan int.")
+		assert.Equal(t, defaultRegistry.failures[1].String(), "LOAD_PORT env has an invalid default on field Port, must be an integer.")
+		assert.Equal(t, defaultRegistry.failures[2].String(), `LOAD_NAME env has an unknown option "optional" on field Name.`)
+		assert.Contains(t, defaultRegistry.failures[3].String(), "env.Load requires a pointer to a struct")
+		reset()
+	})
+	t.Run("Happy, untagged structs without env tags are left alone", func(t *testing.T) {
+		init()
+		var config struct {
+			Logger   *log.Logger
+			Node     *untaggedNode
+			Tree     *loadNode
+			Database *databaseConfig
+		}
+		Load(&config)
+
+		assert.Nil(t, config.Logger)
+		assert.Nil(t, config.Node)
+		assert.NotNil(t, config.Tree)
+		assert.Nil(t, config.Tree.Next)
+		assert.NotNil(t, config.Database)
+		reset()
+	})
+
+	t.Run("Unhappy, required field with a default", func(t *testing.T) {
+		init()
+		var config struct {
+			Port int `env:"LOAD_PORT,required" default:"8080"`
+		}
+		Load(&config)
+
+		assert.Equal(t, 0, config.Port)
+		assert.Equal(t, "LOAD_PORT env is required and has a default on field Port, use required=<profiles> to require it only in some profiles.", defaultRegistry.failures[0].String())
+		assert.Equal(t, KindConfiguration, defaultRegistry.failures[0].Kind)
+		reset()
+	})
+}
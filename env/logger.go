@@ -0,0 +1,47 @@
+package env
+
+import (
+	"log"
+)
+
+// Logger receives the issues reported by Assert, AssertErr and a Reloader.
+// Each Issue carries the env name, description and source as structured
+// fields, see Issue.Fields.
+type Logger interface {
+	Log(severity Severity, issues []Issue)
+}
+
+// StdLogger writes the issues of a call as one entry through Logger, or
+// the standard log package when it is nil. It is the default Logger.
+type StdLogger struct {
+	Logger *log.Logger
+}
+
+func (l StdLogger) Log(severity Severity, issues []Issue) {
+	if l.Logger == nil {
+		log.Println(joinIssues(issues))
+		return
+	}
+	l.Logger.Println(joinIssues(issues))
+}
+
+func SetLogger(logger Logger) {
+	defaultRegistry.SetLogger(logger)
+}
+
+// SetLogger replaces the Logger of r, StdLogger is used again when logger
+// is nil.
+func (r *Registry) SetLogger(logger Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.log = logger
+}
+
+func (r *Registry) logger() Logger {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.log == nil {
+		return StdLogger{}
+	}
+	return r.log
+}
@@ -0,0 +1,102 @@
+package env
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries map[Severity][]Issue
+}
+
+func (l *recordingLogger) Log(severity Severity, issues []Issue) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.entries == nil {
+		l.entries = map[Severity][]Issue{}
+	}
+	l.entries[severity] = append(l.entries[severity], issues...)
+}
+
+func (l *recordingLogger) get(severity Severity) []Issue {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.entries[severity]
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("Happy, custom logger receives structured issues", func(t *testing.T) {
+		logger := &recordingLogger{}
+		registry := NewRegistry(Map("test", map[string]string{"LOGGER_PORT": "http"}))
+		registry.SetLogger(logger)
+		_ = registry.WarnIfEmpty("LOGGER_NAME", "service name")
+		_ = registry.RequireInt("LOGGER_PORT", "listening port")
+
+		assert.Panics(t, func() {
+			registry.Assert()
+		})
+
+		warnings := logger.get(SeverityWarning)
+		assert.Len(t, warnings, 1)
+		assert.Equal(t, map[string]string{
+			"env":         "LOGGER_NAME",
+			"description": "service name",
+			"kind":        "empty",
+			"severity":    "warning",
+		}, warnings[0].Fields())
+
+		failures := logger.get(SeverityFailure)
+		assert.Len(t, failures, 1)
+		assert.Equal(t, map[string]string{
+			"env":         "LOGGER_PORT",
+			"description": "listening port",
+			"source":      "test",
+			"kind":        "invalid",
+			"severity":    "failure",
+		}, failures[0].Fields())
+	})
+
+	t.Run("Happy, std logger with its own log.Logger", func(t *testing.T) {
+		var buffer bytes.Buffer
+		registry := NewRegistry(Map("test", nil))
+		registry.SetLogger(StdLogger{Logger: log.New(&buffer, "env: ", 0)})
+		_ = registry.WarnIfEmpty("LOGGER_A")
+		_ = registry.WarnIfEmpty("LOGGER_B")
+
+		assert.Nil(t, registry.AssertErr())
+		assert.Equal(t, "env: LOGGER_A env is empty, it may be needed.\nLOGGER_B env is empty, it may be needed.\n", buffer.String())
+	})
+
+	t.Run("Unhappy, rejected reloads are logged", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "logger")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := writeFile(t, dir, "flags.env", "LOGGER_MODE=fast\n")
+
+		logger := &recordingLogger{}
+		registry := NewRegistry(Map("test", nil))
+		registry.SetLogger(logger)
+		reloader, err := registry.NewReloader(path, Dotenv)
+		assert.Nil(t, err)
+		_ = reloader.Register("LOGGER_MODE", "safe")
+		stop := reloader.Start(10 * time.Millisecond)
+		defer stop()
+
+		writeFile(t, dir, "flags.env", "NOT A PAIR\n")
+		assert.Eventually(t, func() bool {
+			return len(logger.get(SeverityFailure)) > 0
+		}, time.Second, 10*time.Millisecond)
+		issue := logger.get(SeverityFailure)[0]
+		assert.Equal(t, path, issue.Source)
+		assert.Contains(t, issue.String(), "cannot be reloaded")
+	})
+}
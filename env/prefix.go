@@ -0,0 +1,16 @@
+package env
+
+func WithPrefix(prefix string) *Registry {
+	return defaultRegistry.WithPrefix(prefix)
+}
+
+// WithPrefix returns a view of r prepending prefix to every env name it
+// looks up. The view shares the pending issues, the inventory and the
+// sources of r, so Assert on either reports the full names.
+func (r *Registry) WithPrefix(prefix string) *Registry {
+	return &Registry{shared: r.shared, prefix: r.prefix + prefix}
+}
+
+func (r *Registry) name(envName string) string {
+	return r.prefix + envName
+}
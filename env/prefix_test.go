@@ -0,0 +1,57 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWithPrefix(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		registry := NewRegistry(Map("test", map[string]string{
+			"PAYMENT_URL":     "https://payment.local",
+			"PAYMENT_API_URL": "https://api.payment.local",
+			"NOTIFY_PORT":     "9090",
+		}))
+		payment := registry.WithPrefix("PAYMENT_")
+		notify := registry.WithPrefix("NOTIFY_")
+
+		assert.Equal(t, "https://payment.local", payment.Require("URL"))
+		assert.Equal(t, "api.payment.local", payment.WithPrefix("API_").RequireURL("URL").Host)
+		assert.Equal(t, 9090, notify.DefaultInt("PORT", 8080))
+		assert.Equal(t, "test", payment.SourceOf("URL"))
+
+		var config struct {
+			Port int `env:"PORT,required"`
+		}
+		notify.Load(&config)
+		assert.Equal(t, 9090, config.Port)
+
+		names := []string{}
+		for _, v := range registry.Inventory() {
+			names = append(names, v.Name)
+		}
+		assert.Equal(t, []string{"PAYMENT_URL", "PAYMENT_API_URL", "NOTIFY_PORT"}, names)
+		assert.Nil(t, registry.AssertErr())
+	})
+
+	t.Run("Unhappy, messages show the full name", func(t *testing.T) {
+		registry := NewRegistry(Map("test", map[string]string{"PAYMENT_TIMEOUT": "soon"}))
+		payment := registry.WithPrefix("PAYMENT_")
+
+		_ = payment.Require("SECRET", "payment secret")
+		_ = payment.RequireDuration("TIMEOUT")
+		_ = payment.WarnIfEmpty("WEBHOOK")
+		payment.Validate("TIMEOUT", OneOf("1s", "2s"))
+		var config struct {
+			Channel chan int `env:"CHANNEL"`
+		}
+		payment.Load(&config)
+
+		err := registry.AssertErr()
+		assert.EqualError(t, err, "PAYMENT_SECRET env is required. (payment secret)\n"+
+			"PAYMENT_TIMEOUT env must be a duration.\n"+
+			"PAYMENT_TIMEOUT env must be one of 1s, 2s.\n"+
+			"PAYMENT_CHANNEL env cannot be loaded into field Channel of unsupported type chan int.")
+		assert.Equal(t, "PAYMENT_WEBHOOK", err.(*AssertionError).Warnings()[0].Name)
+	})
+}
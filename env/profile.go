@@ -0,0 +1,81 @@
+package env
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultProfileEnv holds the active profile unless another env is
+// designated with SetProfileEnv.
+const DefaultProfileEnv = "APP_ENV"
+
+const (
+	ProfileDevelopment = "development"
+	ProfileStaging     = "staging"
+	ProfileProduction  = "production"
+)
+
+func SetProfileEnv(envName string) {
+	defaultRegistry.SetProfileEnv(envName)
+}
+
+func Profile() string {
+	return defaultRegistry.Profile()
+}
+
+func IsProfile(profiles ...string) bool {
+	return defaultRegistry.IsProfile(profiles...)
+}
+
+func RequireIn(profiles []string, envName string, defaultValue string, description ...string) string {
+	return defaultRegistry.RequireIn(profiles, envName, defaultValue, description...)
+}
+
+func (r *Registry) SetProfileEnv(envName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profileEnv = envName
+}
+
+// Profile returns the active profile, ProfileDevelopment when the profile
+// env is not set. The profile env is never prefixed.
+func (r *Registry) Profile() string {
+	r.mu.Lock()
+	profileEnv := r.profileEnv
+	r.mu.Unlock()
+
+	res := r.resolve(profileEnv)
+	if profile := strings.TrimSpace(res.value); res.found && profile != "" {
+		return profile
+	}
+	return ProfileDevelopment
+}
+
+func (r *Registry) IsProfile(profiles ...string) bool {
+	active := r.Profile()
+	for _, profile := range profiles {
+		if strings.EqualFold(profile, active) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireIn behaves like Require when one of profiles is active and like
+// Default elsewhere.
+func (r *Registry) RequireIn(profiles []string, envName string, defaultValue string, description ...string) string {
+	if r.IsProfile(profiles...) {
+		return r.Require(envName, description...)
+	}
+	return r.defaultParsed(envName, stringParser, defaultValue, description).(string)
+}
+
+// profileOverlays returns the existing overlay of each dotenv path for the
+// active profile, .env.production for .env in production.
+func (r *Registry) profileOverlays(path string) []string {
+	overlay := path + "." + r.Profile()
+	if _, err := os.Stat(overlay); err != nil {
+		return nil
+	}
+	return []string{overlay}
+}
@@ -0,0 +1,71 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestProfile(t *testing.T) {
+	t.Run("Happy, development when not set", func(t *testing.T) {
+		registry := NewRegistry(Map("test", nil))
+		assert.Equal(t, ProfileDevelopment, registry.Profile())
+		assert.True(t, registry.IsProfile(ProfileDevelopment))
+		assert.False(t, registry.IsProfile(ProfileProduction))
+	})
+
+	t.Run("Happy, designated profile env", func(t *testing.T) {
+		registry := NewRegistry(Map("test", map[string]string{"DEPLOY_ENV": "Staging"}))
+		registry.SetProfileEnv("DEPLOY_ENV")
+		assert.Equal(t, "Staging", registry.Profile())
+		assert.True(t, registry.IsProfile(ProfileStaging, ProfileProduction))
+	})
+
+	t.Run("Happy, required only in production", func(t *testing.T) {
+		development := NewRegistry(Map("test", nil))
+		assert.Equal(t, "noop", development.RequireIn([]string{ProfileProduction}, "PROFILE_SENTRY_DSN", "noop", "sentry dsn"))
+		assert.Empty(t, development.Snapshot().Failures)
+
+		production := NewRegistry(Map("test", map[string]string{"APP_ENV": "production"}))
+		assert.Equal(t, "", production.RequireIn([]string{ProfileProduction}, "PROFILE_SENTRY_DSN", "noop", "sentry dsn"))
+		assert.Equal(t, "PROFILE_SENTRY_DSN env is required. (sentry dsn)", joinIssues(production.Snapshot().Failures))
+	})
+
+	t.Run("Happy, required only in production with Load", func(t *testing.T) {
+		type config struct {
+			DSN string `env:"PROFILE_SENTRY_DSN,required=staging|production" default:"noop"`
+		}
+
+		var developmentConfig config
+		development := NewRegistry(Map("test", nil))
+		development.Load(&developmentConfig)
+		assert.Equal(t, "noop", developmentConfig.DSN)
+		assert.Empty(t, development.Snapshot().Failures)
+
+		var productionConfig config
+		production := NewRegistry(Map("test", map[string]string{"APP_ENV": "production"}))
+		production.Load(&productionConfig)
+		assert.Equal(t, "PROFILE_SENTRY_DSN env is required.", joinIssues(production.Snapshot().Failures))
+	})
+
+	t.Run("Happy, profile dotenv overlay", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "profile")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := writeFile(t, dir, ".env", "PROFILE_HOST=localhost\nPROFILE_PORT=8080\n")
+		overlay := writeFile(t, dir, ".env.production", "PROFILE_HOST=prod.local\n")
+
+		registry := NewRegistry(Map("test", map[string]string{"APP_ENV": "production"}))
+		assert.Nil(t, registry.LoadDotenv(path))
+		assert.Equal(t, "prod.local", registry.Require("PROFILE_HOST"))
+		assert.Equal(t, overlay, registry.SourceOf("PROFILE_HOST"))
+		assert.Equal(t, "8080", registry.Require("PROFILE_PORT"))
+
+		staging := NewRegistry(Map("test", map[string]string{"APP_ENV": "staging"}))
+		assert.Nil(t, staging.LoadDotenv(path))
+		assert.Equal(t, "localhost", staging.Require("PROFILE_HOST"))
+	})
+}
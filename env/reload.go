@@ -0,0 +1,276 @@
+package env
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Change is a value changed by a reload.
+type Change struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// Reloader keeps the variables registered on it in sync with a file source
+// layered below the sources of a Registry. Get never blocks, a reload
+// resolves every registered variable on a copy of the Registry and is
+// rejected as a whole when any of them has a failure.
+type Reloader struct {
+	registry *Registry
+	path     string
+	load     func(path string) (Source, error)
+	source   *reloadableSource
+	values   atomic.Value
+
+	mu          sync.Mutex
+	defaults    map[string]string
+	names       []string
+	subscribers []func(changes []Change)
+	// modTime and size are the stat of the file at the last reload, and
+	// rejected the hash of its content when that reload was rejected.
+	modTime  time.Time
+	size     int64
+	rejected *[sha256.Size]byte
+}
+
+type reloadableSource struct {
+	name    string
+	current atomic.Value
+}
+
+func (s *reloadableSource) Lookup(envName string) (string, bool) {
+	return s.current.Load().(Source).Lookup(envName)
+}
+
+func (s *reloadableSource) Name() string {
+	return s.name
+}
+
+func NewReloader(path string, load func(path string) (Source, error)) (*Reloader, error) {
+	return defaultRegistry.NewReloader(path, load)
+}
+
+// NewReloader loads the file at path with load, such as Dotenv or JSONFile,
+// and adds it below the sources of r.
+func (r *Registry) NewReloader(path string, load func(path string) (Source, error)) (*Reloader, error) {
+	source, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	rl := &Reloader{
+		registry: r,
+		path:     path,
+		load:     load,
+		source:   &reloadableSource{name: path},
+		defaults: map[string]string{},
+	}
+	rl.source.current.Store(source)
+	rl.values.Store(map[string]string{})
+	if info, err := os.Stat(path); err == nil {
+		rl.modTime, rl.size = info.ModTime(), info.Size()
+	}
+	r.AddSource(rl.source)
+	return rl, nil
+}
+
+// Register resolves envName like Default and keeps it up to date on every
+// reload, its issues are queued on the Registry for Assert.
+func (rl *Reloader) Register(envName string, defaultValue string) string {
+	value := rl.registry.Default(envName, defaultValue)
+	envName = rl.registry.name(envName)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if _, ok := rl.defaults[envName]; !ok {
+		rl.names = append(rl.names, envName)
+	}
+	rl.defaults[envName] = defaultValue
+
+	values := rl.copyValues()
+	values[envName] = value
+	rl.values.Store(values)
+	return value
+}
+
+// Get returns the current value of a registered env without locking.
+func (rl *Reloader) Get(envName string) string {
+	return rl.values.Load().(map[string]string)[rl.registry.name(envName)]
+}
+
+// Subscribe calls fn with the changed values after every accepted reload.
+func (rl *Reloader) Subscribe(fn func(changes []Change)) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.subscribers = append(rl.subscribers, fn)
+}
+
+// Reload reads the file again. It returns the load error or an
+// *AssertionError when the new values are rejected, the current values are
+// kept in both cases and the poller retries once the file changes.
+func (rl *Reloader) Reload() error {
+	rl.mu.Lock()
+	if info, err := os.Stat(rl.path); err == nil {
+		rl.modTime, rl.size = info.ModTime(), info.Size()
+	}
+	rl.rejected = nil
+	source, err := rl.load(rl.path)
+	if err != nil {
+		rl.reject()
+		rl.mu.Unlock()
+		return err
+	}
+
+	candidate := rl.registry.replacingSource(rl.source, source)
+	values := make(map[string]string, len(rl.names))
+	for _, name := range rl.names {
+		values[name] = candidate.Default(name, rl.defaults[name])
+	}
+	if state := candidate.Snapshot(); len(state.Failures) > 0 {
+		rl.reject()
+		rl.mu.Unlock()
+		return &AssertionError{Issues: append(state.Failures, state.Warnings...)}
+	}
+
+	old := rl.values.Load().(map[string]string)
+	rl.source.current.Store(source)
+	rl.values.Store(values)
+	var changes []Change
+	for _, name := range rl.names {
+		if old[name] != values[name] {
+			changes = append(changes, Change{Name: name, Old: old[name], New: values[name]})
+		}
+	}
+	subscribers := rl.subscribers
+	rl.mu.Unlock()
+
+	if len(changes) > 0 {
+		for _, fn := range subscribers {
+			fn(changes)
+		}
+	}
+	return nil
+}
+
+// Start polls the file every interval and reloads it when it changed,
+// failed reloads are reported to the Logger of the Registry. The returned function stops polling.
+func (rl *Reloader) Start(interval time.Duration) func() {
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if !rl.changed() {
+					continue
+				}
+				if err := rl.Reload(); err != nil {
+					rl.registry.logger().Log(SeverityFailure, reloadIssues(rl.path, err))
+				}
+			}
+		}
+	}()
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// changed reports whether the file differs from the last reload. After a
+// rejected reload the content is compared too, a rewrite keeping the same
+// modification time and size is picked up without reporting the same
+// failure on every poll.
+func (rl *Reloader) changed() bool {
+	info, err := os.Stat(rl.path)
+	if err != nil {
+		return false
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if !info.ModTime().Equal(rl.modTime) || info.Size() != rl.size {
+		return true
+	}
+	if rl.rejected == nil {
+		return false
+	}
+	sum, err := fileHash(rl.path)
+	return err == nil && sum != *rl.rejected
+}
+
+// reject remembers the content of a rejected file.
+func (rl *Reloader) reject() {
+	if sum, err := fileHash(rl.path); err == nil {
+		rl.rejected = &sum
+	}
+}
+
+func fileHash(path string) ([sha256.Size]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(content), nil
+}
+
+func reloadIssues(path string, err error) []Issue {
+	if assertionError, ok := err.(*AssertionError); ok {
+		return assertionError.Failures()
+	}
+	issue := failureIssue("", KindConfiguration, fmt.Sprintf("%s cannot be reloaded, %v.", path, err))
+	issue.Source = path
+	return []Issue{issue}
+}
+
+func (rl *Reloader) copyValues() map[string]string {
+	current := rl.values.Load().(map[string]string)
+	values := make(map[string]string, len(current)+1)
+	for k, v := range current {
+		values[k] = v
+	}
+	return values
+}
+
+// replacingSource returns an unprefixed copy of r with current replaced by
+// next among its sources, its issues are kept apart from the ones of r.
+func (r *Registry) replacingSource(current Source, next Source) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sources := make([]Source, len(r.sources))
+	for i, source := range r.sources {
+		if source == current {
+			source = next
+		}
+		sources[i] = source
+	}
+	variables := make(map[string]Variable, len(r.variables))
+	for k, v := range r.variables {
+		variables[k] = v
+	}
+	rules := make(map[string][]Rule, len(r.rules))
+	for k, v := range r.rules {
+		rules[k] = v
+	}
+	aliases := make(map[string][]string, len(r.aliases))
+	for k, v := range r.aliases {
+		aliases[k] = v
+	}
+	return &Registry{shared: &shared{
+		sources:           sources,
+		variables:         variables,
+		variableNames:     append([]string(nil), r.variableNames...),
+		rules:             rules,
+		aliases:           aliases,
+		decryptionKeyEnv:  r.decryptionKeyEnv,
+		decryptionKeyFile: r.decryptionKeyFile,
+		profileEnv:        r.profileEnv,
+	}}
+}
@@ -0,0 +1,132 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Run("Happy, reload notifies the changes", func(t *testing.T) {
+		path := writeFile(t, dir, "flags.env", "RELOAD_RATE_LIMIT=100\nRELOAD_SEARCH=on\n")
+		registry := NewRegistry(Map("test", map[string]string{"RELOAD_PINNED": "pinned"}))
+		reloader, err := registry.NewReloader(path, Dotenv)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "100", reloader.Register("RELOAD_RATE_LIMIT", "10"))
+		assert.Equal(t, "on", reloader.Register("RELOAD_SEARCH", "off"))
+		assert.Equal(t, "pinned", reloader.Register("RELOAD_PINNED", ""))
+		assert.Equal(t, path, registry.SourceOf("RELOAD_RATE_LIMIT"))
+
+		var received []Change
+		reloader.Subscribe(func(changes []Change) {
+			received = append(received, changes...)
+		})
+
+		writeFile(t, dir, "flags.env", "RELOAD_RATE_LIMIT=200\nRELOAD_PINNED=ignored\n")
+		assert.Nil(t, reloader.Reload())
+		assert.False(t, reloader.changed())
+		assert.Equal(t, "200", reloader.Get("RELOAD_RATE_LIMIT"))
+		assert.Equal(t, "off", reloader.Get("RELOAD_SEARCH"))
+		assert.Equal(t, "pinned", reloader.Get("RELOAD_PINNED"))
+		assert.Equal(t, "200", registry.Require("RELOAD_RATE_LIMIT"))
+		assert.Equal(t, []Change{
+			{Name: "RELOAD_RATE_LIMIT", Old: "100", New: "200"},
+			{Name: "RELOAD_SEARCH", Old: "on", New: "off"},
+		}, received)
+	})
+
+	t.Run("Unhappy, a failing rule rejects the whole reload", func(t *testing.T) {
+		path := writeFile(t, dir, "limits.env", "RELOAD_RATE_LIMIT=100\nRELOAD_MODE=fast\n")
+		registry := NewRegistry(Map("test", nil))
+		registry.Validate("RELOAD_MODE", OneOf("fast", "safe"))
+		reloader, err := registry.NewReloader(path, Dotenv)
+		assert.Nil(t, err)
+		_ = reloader.Register("RELOAD_RATE_LIMIT", "10")
+		_ = reloader.Register("RELOAD_MODE", "safe")
+
+		notified := false
+		reloader.Subscribe(func(changes []Change) {
+			notified = true
+		})
+
+		writeFile(t, dir, "limits.env", "RELOAD_RATE_LIMIT=200\nRELOAD_MODE=reckless\n")
+		err = reloader.Reload()
+		assert.EqualError(t, err, "RELOAD_MODE env must be one of fast, safe.")
+		assert.Equal(t, "100", reloader.Get("RELOAD_RATE_LIMIT"))
+		assert.Equal(t, "fast", reloader.Get("RELOAD_MODE"))
+		assert.Equal(t, "100", registry.Require("RELOAD_RATE_LIMIT"))
+		assert.Empty(t, registry.Snapshot().Failures)
+		assert.False(t, notified)
+
+		writeFile(t, dir, "limits.env", "RELOAD_MODE=\"unterminated\n")
+		assert.NotNil(t, reloader.Reload())
+		assert.Equal(t, "fast", reloader.Get("RELOAD_MODE"))
+	})
+
+	t.Run("Happy, polling picks up changes", func(t *testing.T) {
+		path := writeFile(t, dir, "poll.env", "RELOAD_FLAG=off\n")
+		registry := NewRegistry(Map("test", nil))
+		reloader, err := registry.NewReloader(path, Dotenv)
+		assert.Nil(t, err)
+		_ = reloader.Register("RELOAD_FLAG", "off")
+
+		var mu sync.Mutex
+		var received []Change
+		reloader.Subscribe(func(changes []Change) {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, changes...)
+		})
+		stop := reloader.Start(10 * time.Millisecond)
+		defer stop()
+
+		writeFile(t, dir, "poll.env", "RELOAD_FLAG=on\n")
+		assert.Eventually(t, func() bool {
+			return reloader.Get("RELOAD_FLAG") == "on"
+		}, time.Second, 10*time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []Change{{Name: "RELOAD_FLAG", Old: "off", New: "on"}}, received)
+	})
+	t.Run("Happy, polling retries after a rejected reload", func(t *testing.T) {
+		path := writeFile(t, dir, "retry.env", "RELOAD_MODE=fast\n")
+		logger := &recordingLogger{}
+		registry := NewRegistry(Map("test", nil))
+		registry.SetLogger(logger)
+		registry.Validate("RELOAD_MODE", OneOf("fast", "safe"))
+		reloader, err := registry.NewReloader(path, Dotenv)
+		assert.Nil(t, err)
+		_ = reloader.Register("RELOAD_MODE", "fast")
+
+		modTime := time.Now().Add(time.Hour).Truncate(time.Second)
+		writeFile(t, dir, "retry.env", "RELOAD_MODE=slow\n")
+		assert.Nil(t, os.Chtimes(path, modTime, modTime))
+		stop := reloader.Start(10 * time.Millisecond)
+		defer stop()
+
+		assert.Eventually(t, func() bool {
+			return len(logger.get(SeverityFailure)) > 0
+		}, time.Second, 10*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+		assert.Len(t, logger.get(SeverityFailure), 1, "an unchanged rejected file is reported once")
+		assert.Equal(t, "fast", reloader.Get("RELOAD_MODE"))
+
+		writeFile(t, dir, "retry.env", "RELOAD_MODE=safe\n")
+		assert.Nil(t, os.Chtimes(path, modTime, modTime))
+		assert.Eventually(t, func() bool {
+			return reloader.Get("RELOAD_MODE") == "safe"
+		}, time.Second, 10*time.Millisecond)
+		assert.False(t, reloader.changed())
+	})
+}
@@ -0,0 +1,94 @@
+package env
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// FileSuffix names the env holding the path of a file to read the value
+// from when the env itself is not set, as orchestrators mount secrets.
+const FileSuffix = "_FILE"
+
+type resolution struct {
+	value  string
+	source string
+	found  bool
+	issues []Issue
+}
+
+// get resolves the declared variable, records it in the inventory and
+// queues the issues found on the way with its description attached.
+func (r *Registry) get(v Variable) (string, string, bool) {
+	res := r.resolve(v.Name)
+	if res.found && !hasFailure(res.issues) {
+		for _, issue := range checkRules(v.Name, res.value, r.rulesOf(v.Name)) {
+			issue.Source = res.source
+			res.issues = append(res.issues, issue)
+		}
+	}
+	v.Set, v.Source = res.found, res.source
+	r.declare(v)
+	for _, issue := range res.issues {
+		if issue.Description == "" {
+			issue.Description = v.Description
+		}
+		r.report(issue)
+	}
+	return res.value, res.source, res.found
+}
+
+func (r *Registry) resolve(envName string) resolution {
+	return r.resolveExpanding(envName, nil)
+}
+
+// resolveExpanding resolves envName while the names in stack are being
+// expanded. Encrypted values are decrypted but never expanded.
+func (r *Registry) resolveExpanding(envName string, stack []string) resolution {
+	res := r.resolveAliases(envName)
+	if !res.found || hasFailure(res.issues) {
+		return res
+	}
+	if strings.HasPrefix(res.value, EncryptedPrefix) {
+		return r.decrypt(envName, res)
+	}
+	return r.expand(envName, res, stack)
+}
+
+func (r *Registry) resolveFile(envName string) resolution {
+	env, source, found := r.lookup(envName)
+	path, _, fileFound := r.lookup(envName + FileSuffix)
+	switch {
+	case found && fileFound:
+		return resolution{value: env, source: source, found: true, issues: []Issue{
+			failureIssue(envName, KindInvalid, fmt.Sprintf("%s env and %s%s env are both set, only one is allowed.", envName, envName, FileSuffix)),
+		}}
+	case fileFound:
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return resolution{found: true, issues: []Issue{
+				failureIssue(envName, KindInvalid, fmt.Sprintf("%s%s env points to an unreadable file, %v.", envName, FileSuffix, err)),
+			}}
+		}
+		value := strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r")
+		return resolution{value: value, source: path, found: true}
+	}
+	return resolution{value: env, source: source, found: found}
+}
+
+func (r *Registry) report(issue Issue) {
+	if issue.Severity == SeverityWarning {
+		r.preWarn(issue)
+		return
+	}
+	r.prePanic(issue)
+}
+
+func hasFailure(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityFailure {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileIndirection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "db_password")
+	if err := ioutil.WriteFile(path, []byte("s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	reset := func() {
+		_ = os.Unsetenv("FILE_PASSWORD")
+		_ = os.Unsetenv("FILE_PASSWORD_FILE")
+	}
+
+	t.Run("Happy, value read from file", func(t *testing.T) {
+		_ = os.Setenv("FILE_PASSWORD_FILE", path)
+		defer reset()
+
+		registry := NewRegistry()
+		assert.Equal(t, "s3cret", registry.Require("FILE_PASSWORD"))
+		assert.Equal(t, "s3cret", registry.RequireSecret("FILE_PASSWORD").Value())
+		assert.Equal(t, path, registry.SourceOf("FILE_PASSWORD"))
+		assert.Empty(t, registry.Snapshot().Failures)
+	})
+
+	t.Run("Unhappy, neither is set", func(t *testing.T) {
+		registry := NewRegistry()
+		_ = registry.Require("FILE_PASSWORD", "database password")
+		assert.Equal(t, "FILE_PASSWORD env is required. (database password)", joinIssues(registry.Snapshot().Failures))
+	})
+
+	t.Run("Unhappy, both are set", func(t *testing.T) {
+		_ = os.Setenv("FILE_PASSWORD", "inline")
+		_ = os.Setenv("FILE_PASSWORD_FILE", path)
+		defer reset()
+
+		registry := NewRegistry()
+		_ = registry.Require("FILE_PASSWORD", "database password")
+		failures := registry.Snapshot().Failures
+		assert.Len(t, failures, 1)
+		assert.Equal(t, "FILE_PASSWORD env and FILE_PASSWORD_FILE env are both set, only one is allowed. (database password)", failures[0].String())
+		assert.Equal(t, KindInvalid, failures[0].Kind)
+	})
+
+	t.Run("Unhappy, file is unreadable", func(t *testing.T) {
+		missing := filepath.Join(dir, "missing")
+		_ = os.Setenv("FILE_PASSWORD_FILE", missing)
+		defer reset()
+
+		registry := NewRegistry()
+		assert.Equal(t, "", registry.Require("FILE_PASSWORD"))
+		failures := registry.Snapshot().Failures
+		assert.Len(t, failures, 1)
+		assert.Equal(t, "FILE_PASSWORD", failures[0].Name)
+		assert.Contains(t, failures[0].String(), "FILE_PASSWORD_FILE env points to an unreadable file, open "+missing)
+	})
+}
@@ -0,0 +1,79 @@
+package env
+
+import (
+	"strings"
+)
+
+// Rule checks the value of an env, Expect completes the "must be" sentence
+// reported when Check fails.
+type Rule struct {
+	Expect string
+	Check  func(value string) bool
+}
+
+// Is turns a predicate such as validator.IsURL into a Rule.
+func Is(check func(value string) bool, expect string) Rule {
+	return Rule{Expect: expect, Check: check}
+}
+
+func OneOf(values ...string) Rule {
+	return Rule{
+		Expect: "one of " + strings.Join(values, ", "),
+		Check: func(value string) bool {
+			for _, v := range values {
+				if value == v {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+func Validate(envName string, rules ...Rule) {
+	defaultRegistry.Validate(envName, rules...)
+}
+
+// Validate attaches rules checked whenever envName is looked up and set, a
+// failing rule is reported by Assert. When envName has already been looked
+// up the rules are checked right away.
+func (r *Registry) Validate(envName string, rules ...Rule) {
+	envName = r.name(envName)
+	r.mu.Lock()
+	if r.rules == nil {
+		r.rules = map[string][]Rule{}
+	}
+	r.rules[envName] = append(r.rules[envName], rules...)
+	v, declared := r.variables[envName]
+	r.mu.Unlock()
+
+	if !declared || !v.Set {
+		return
+	}
+	res := r.resolve(envName)
+	if res.found && !hasFailure(res.issues) {
+		for _, issue := range checkRules(envName, res.value, rules) {
+			issue.Description = v.Description
+			issue.Source = res.source
+			r.report(issue)
+		}
+	}
+}
+
+func (r *Registry) rulesOf(envName string) []Rule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rules[envName]
+}
+
+func checkRules(envName string, value string, rules []Rule) []Issue {
+	var issues []Issue
+	for _, rule := range rules {
+		if !rule.Check(value) {
+			issue := invalidIssue(envName, rule.Expect, nil)
+			issue.Severity = SeverityFailure
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
@@ -0,0 +1,63 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/gridwhizth/universe/validator"
+	"os"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	init := func() {
+		_ = os.Setenv("RULE_CALLBACK_URL", "not a url")
+		_ = os.Setenv("RULE_LOG_LEVEL", "info")
+	}
+	reset := func() {
+		_ = os.Unsetenv("RULE_CALLBACK_URL")
+		_ = os.Unsetenv("RULE_LOG_LEVEL")
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		init()
+		defer reset()
+
+		registry := NewRegistry()
+		registry.Validate("RULE_LOG_LEVEL", OneOf("debug", "info", "warn"))
+		registry.Validate("RULE_MISSING", Is(validator.IsURL, "a valid URL"))
+		assert.Equal(t, "info", registry.Require("RULE_LOG_LEVEL"))
+		_ = registry.WarnIfEmpty("RULE_MISSING")
+
+		assert.Empty(t, registry.Snapshot().Failures)
+	})
+
+	t.Run("Unhappy, rule declared before the lookup", func(t *testing.T) {
+		init()
+		defer reset()
+		_ = os.Setenv("RULE_LOG_LEVEL", "verbose")
+
+		registry := NewRegistry()
+		registry.Validate("RULE_CALLBACK_URL", Is(validator.IsURL, "a valid URL"))
+		registry.Validate("RULE_LOG_LEVEL", OneOf("debug", "info", "warn"))
+		_ = registry.Require("RULE_CALLBACK_URL", "payment callback")
+		_ = registry.Default("RULE_LOG_LEVEL", "info")
+		_ = registry.Require("RULE_MISSING")
+
+		failures := registry.Snapshot().Failures
+		assert.Equal(t, "RULE_CALLBACK_URL env must be a valid URL. (payment callback)\n"+
+			"RULE_LOG_LEVEL env must be one of debug, info, warn.\n"+
+			"RULE_MISSING env is required.", joinIssues(failures))
+		assert.Equal(t, KindInvalid, failures[0].Kind)
+		assert.Equal(t, ProcessSource, failures[0].Source)
+	})
+
+	t.Run("Unhappy, rule declared after the lookup", func(t *testing.T) {
+		init()
+		defer reset()
+
+		registry := NewRegistry()
+		_ = registry.Require("RULE_CALLBACK_URL", "payment callback")
+		registry.Validate("RULE_CALLBACK_URL", Is(validator.IsURL, "a valid URL"))
+
+		assert.Equal(t, "RULE_CALLBACK_URL env must be a valid URL. (payment callback)", joinIssues(registry.Snapshot().Failures))
+	})
+}
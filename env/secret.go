@@ -0,0 +1,76 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const redacted = "[REDACTED]"
+
+// Secret holds a sensitive env value which is redacted whenever it is
+// printed or marshalled, Value returns the actual value. The value is kept
+// behind a pointer so even an unexported Secret field printed through
+// reflection only shows an address.
+type Secret struct {
+	value *string
+}
+
+var secretParser = parser{kind: "a secret", zero: Secret{}, secret: true, parse: func(env string) (interface{}, error) {
+	return NewSecret(env), nil
+}}
+
+func NewSecret(value string) Secret {
+	return Secret{value: &value}
+}
+
+func (s Secret) Value() string {
+	if s.value == nil {
+		return ""
+	}
+	return *s.value
+}
+
+func (s Secret) String() string {
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return redacted
+}
+
+func (s Secret) Format(f fmt.State, verb rune) {
+	_, _ = io.WriteString(f, redacted)
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redacted)
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+func RequireSecret(envName string, description ...string) Secret {
+	return defaultRegistry.RequireSecret(envName, description...)
+}
+
+func (r *Registry) RequireSecret(envName string, description ...string) Secret {
+	return r.requireParsed(envName, secretParser, description).(Secret)
+}
+
+func WarnIfEmptySecret(envName string, description ...string) Secret {
+	return defaultRegistry.WarnIfEmptySecret(envName, description...)
+}
+
+func (r *Registry) WarnIfEmptySecret(envName string, description ...string) Secret {
+	return r.warnIfEmptyParsed(envName, secretParser, description).(Secret)
+}
+
+func DefaultSecret(envName string, defaultValue string) Secret {
+	return defaultRegistry.DefaultSecret(envName, defaultValue)
+}
+
+func (r *Registry) DefaultSecret(envName string, defaultValue string) Secret {
+	return r.defaultParsed(envName, secretParser, NewSecret(defaultValue), nil).(Secret)
+}
@@ -0,0 +1,82 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestSecret(t *testing.T) {
+	t.Run("Happy, secret is redacted", func(t *testing.T) {
+		secret := NewSecret("api-key")
+		config := struct {
+			Key    Secret
+			hidden Secret
+		}{Key: secret, hidden: secret}
+
+		assert.Equal(t, "api-key", secret.Value())
+		for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%d"} {
+			assert.NotContains(t, fmt.Sprintf(format, secret), "api-key", format)
+			assert.NotContains(t, fmt.Sprintf(format, config), "api-key", format)
+			assert.NotContains(t, fmt.Sprintf(format, &config), "api-key", format)
+		}
+
+		bytes, err := json.Marshal(config)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"Key":"[REDACTED]"}`, string(bytes))
+	})
+
+	t.Run("Happy, zero secret", func(t *testing.T) {
+		var secret Secret
+		assert.Equal(t, "", secret.Value())
+		assert.Equal(t, "[REDACTED]", secret.String())
+	})
+}
+
+func TestRequireSecret(t *testing.T) {
+	init := func() {
+		Reset()
+		_ = os.Setenv("SECRET_KEY", "api-key")
+	}
+	reset := func() {
+		_ = os.Unsetenv("SECRET_KEY")
+		Reset()
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		init()
+		assert.Equal(t, "api-key", RequireSecret("SECRET_KEY").Value())
+		assert.Equal(t, "api-key", WarnIfEmptySecret("SECRET_KEY").Value())
+		assert.Equal(t, "api-key", DefaultSecret("SECRET_KEY", "fallback").Value())
+		assert.Len(t, defaultRegistry.failures, 0)
+		reset()
+	})
+
+	t.Run("Happy, env not defined, use default instead", func(t *testing.T) {
+		init()
+		assert.Equal(t, "fallback", DefaultSecret("SECRET_KEY_2", "fallback").Value())
+		reset()
+	})
+
+	t.Run("Unhappy, env not defined", func(t *testing.T) {
+		init()
+		assert.Equal(t, "", RequireSecret("SECRET_KEY_2", "payment api key").Value())
+		assert.Equal(t, "", WarnIfEmptySecret("SECRET_KEY_3").Value())
+		assert.Equal(t, defaultRegistry.failures[0].String(), "SECRET_KEY_2 env is required. (payment api key)")
+		assert.Equal(t, defaultRegistry.warnings[0].String(), "SECRET_KEY_3 env is empty, it may be needed.")
+		reset()
+	})
+
+	t.Run("Happy, loaded into a struct", func(t *testing.T) {
+		init()
+		var config struct {
+			Key Secret `env:"SECRET_KEY,required"`
+		}
+		Load(&config)
+		assert.Equal(t, "api-key", config.Key.Value())
+		assert.NotContains(t, fmt.Sprintf("%+v", config), "api-key")
+		reset()
+	})
+}
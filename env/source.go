@@ -0,0 +1,185 @@
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Source provides the values looked up by a Registry, Name identifies it in
+// SourceOf, the inventory and the issues.
+type Source interface {
+	Lookup(envName string) (string, bool)
+	Name() string
+}
+
+type processSource struct{}
+
+type mapSource struct {
+	name   string
+	values map[string]string
+}
+
+type chainSource []Source
+
+func Process() Source {
+	return processSource{}
+}
+
+func (processSource) Lookup(envName string) (string, bool) {
+	return syscall.Getenv(envName)
+}
+
+func (processSource) Name() string {
+	return ProcessSource
+}
+
+// Map returns a Source over a copy of values, handy to inject values in
+// tests without touching the process environment.
+func Map(name string, values map[string]string) Source {
+	copied := make(map[string]string, len(values))
+	for k, v := range values {
+		copied[k] = v
+	}
+	return mapSource{name: name, values: copied}
+}
+
+func (s mapSource) Lookup(envName string) (string, bool) {
+	value, found := s.values[envName]
+	return value, found
+}
+
+func (s mapSource) Name() string {
+	return s.name
+}
+
+func Dotenv(path string) (Source, error) {
+	values, err := ReadDotenv(path)
+	if err != nil {
+		return nil, err
+	}
+	return mapSource{name: path, values: values}, nil
+}
+
+// JSONFile reads a flat JSON object, numbers and booleans are turned into
+// their usual env spelling.
+func JSONFile(path string) (Source, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return scalarSource(path, object)
+}
+
+// YAMLFile reads a flat YAML mapping like JSONFile.
+func YAMLFile(path string) (Source, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := yaml.Unmarshal(content, &object); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return scalarSource(path, object)
+}
+
+func scalarSource(name string, object map[string]interface{}) (Source, error) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make(map[string]string, len(object))
+	for _, key := range keys {
+		switch value := object[key].(type) {
+		case nil:
+			values[key] = ""
+		case string:
+			values[key] = value
+		case bool:
+			values[key] = strconv.FormatBool(value)
+		case json.Number:
+			values[key] = value.String()
+		case int:
+			values[key] = strconv.Itoa(value)
+		case float64:
+			values[key] = strconv.FormatFloat(value, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("%s: %s must be a string, a number or a boolean", name, key)
+		}
+	}
+	return mapSource{name: name, values: values}, nil
+}
+
+// Chain returns a Source looking up each source in turn, the first one
+// holding the env wins.
+func Chain(sources ...Source) Source {
+	return chainSource(append([]Source(nil), sources...))
+}
+
+func (c chainSource) Lookup(envName string) (string, bool) {
+	value, _, found := lookupIn(c, envName)
+	return value, found
+}
+
+func (c chainSource) Name() string {
+	names := make([]string, len(c))
+	for i, source := range c {
+		names[i] = source.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// lookupIn reports the name of the source actually holding envName, looking
+// through chains.
+func lookupIn(source Source, envName string) (string, string, bool) {
+	if chain, ok := source.(chainSource); ok {
+		for _, s := range chain {
+			if value, name, found := lookupIn(s, envName); found {
+				return value, name, true
+			}
+		}
+		return "", "", false
+	}
+	value, found := source.Lookup(envName)
+	if !found {
+		return "", "", false
+	}
+	return value, source.Name(), true
+}
+
+func SetSources(sources ...Source) {
+	defaultRegistry.SetSources(sources...)
+}
+
+func AddSource(source Source) {
+	defaultRegistry.AddSource(source)
+}
+
+// SetSources replaces the sources of r, the first one holding an env wins.
+func (r *Registry) SetSources(sources ...Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append([]Source(nil), sources...)
+}
+
+// AddSource adds a source below the existing ones.
+func (r *Registry) AddSource(source Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append(r.sources, source)
+}
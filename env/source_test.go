@@ -0,0 +1,86 @@
+package env
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Run("Happy, map source", func(t *testing.T) {
+		values := map[string]string{"SOURCE_PORT": "8080"}
+		registry := NewRegistry(Map("test", values))
+		values["SOURCE_PORT"] = "9090"
+
+		assert.Equal(t, 8080, registry.RequireInt("SOURCE_PORT"))
+		assert.Equal(t, "test", registry.SourceOf("SOURCE_PORT"))
+		_ = registry.Require("PATH")
+		assert.Equal(t, "PATH env is required.", joinIssues(registry.Snapshot().Failures))
+	})
+
+	t.Run("Happy, chain reports the source holding the env", func(t *testing.T) {
+		registry := NewRegistry(Chain(
+			Map("overrides", map[string]string{"SOURCE_HOST": "override.local"}),
+			Map("defaults", map[string]string{"SOURCE_HOST": "default.local", "SOURCE_PORT": "8080"}),
+		))
+
+		assert.Equal(t, "override.local", registry.Require("SOURCE_HOST"))
+		assert.Equal(t, "overrides", registry.SourceOf("SOURCE_HOST"))
+		assert.Equal(t, "8080", registry.Require("SOURCE_PORT"))
+		assert.Equal(t, "defaults", registry.SourceOf("SOURCE_PORT"))
+		assert.Equal(t, "overrides, defaults", Chain(Map("overrides", nil), Map("defaults", nil)).Name())
+	})
+
+	t.Run("Happy, file defaults layered under the process environment", func(t *testing.T) {
+		_ = os.Setenv("SOURCE_HOST", "process.local")
+		defer os.Unsetenv("SOURCE_HOST")
+		path := writeFile(t, dir, "config.json", `{"SOURCE_HOST": "json.local", "SOURCE_PORT": 8080, "SOURCE_RATE": 0.5, "SOURCE_DEBUG": true, "SOURCE_EMPTY": null}`)
+		file, err := JSONFile(path)
+		assert.Nil(t, err)
+
+		registry := NewRegistry()
+		registry.AddSource(file)
+		assert.Equal(t, "process.local", registry.Require("SOURCE_HOST"))
+		assert.Equal(t, 8080, registry.RequireInt("SOURCE_PORT"))
+		assert.Equal(t, 0.5, registry.RequireFloat64("SOURCE_RATE"))
+		assert.Equal(t, true, registry.RequireBool("SOURCE_DEBUG"))
+		assert.Equal(t, "", registry.Require("SOURCE_EMPTY"))
+		assert.Equal(t, path, registry.SourceOf("SOURCE_PORT"))
+		assert.Empty(t, registry.Snapshot().Failures)
+	})
+
+	t.Run("Happy, yaml and dotenv files", func(t *testing.T) {
+		yamlPath := writeFile(t, dir, "config.yaml", "SOURCE_HOST: yaml.local\nSOURCE_PORT: 8080\nSOURCE_TIMEOUT: 5s\n")
+		dotenvPath := writeFile(t, dir, ".env", "SOURCE_HOST=dotenv.local\n")
+		yamlFile, err := YAMLFile(yamlPath)
+		assert.Nil(t, err)
+		dotenvFile, err := Dotenv(dotenvPath)
+		assert.Nil(t, err)
+
+		registry := NewRegistry()
+		registry.SetSources(dotenvFile, yamlFile)
+		assert.Equal(t, "dotenv.local", registry.Require("SOURCE_HOST"))
+		assert.Equal(t, 8080, registry.RequireInt("SOURCE_PORT"))
+		assert.Equal(t, "5s", registry.Require("SOURCE_TIMEOUT"))
+	})
+
+	t.Run("Unhappy, nested values are rejected", func(t *testing.T) {
+		jsonPath := writeFile(t, dir, "nested.json", `{"SOURCE_DB": {"HOST": "db.local"}}`)
+		_, err := JSONFile(jsonPath)
+		assert.EqualError(t, err, jsonPath+": SOURCE_DB must be a string, a number or a boolean")
+
+		yamlPath := writeFile(t, dir, "nested.yaml", "SOURCE_HOSTS:\n  - a\n  - b\n")
+		_, err = YAMLFile(yamlPath)
+		assert.EqualError(t, err, yamlPath+": SOURCE_HOSTS must be a string, a number or a boolean")
+
+		_, err = JSONFile(writeFile(t, dir, "invalid.json", `{`))
+		assert.NotNil(t, err)
+	})
+}
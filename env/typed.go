@@ -0,0 +1,277 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type parser struct {
+	kind   string
+	zero   interface{}
+	secret bool
+	parse  func(env string) (interface{}, error)
+	format func(value interface{}) string
+}
+
+var (
+	intParser = parser{kind: "an integer", zero: 0, parse: func(env string) (interface{}, error) {
+		return strconv.Atoi(env)
+	}}
+	int64Parser = parser{kind: "a 64-bit integer", zero: int64(0), parse: func(env string) (interface{}, error) {
+		return strconv.ParseInt(env, 10, 64)
+	}}
+	boolParser = parser{kind: "a boolean", zero: false, parse: func(env string) (interface{}, error) {
+		return strconv.ParseBool(env)
+	}}
+	float64Parser = parser{kind: "a number", zero: float64(0), parse: func(env string) (interface{}, error) {
+		return strconv.ParseFloat(env, 64)
+	}}
+	durationParser = parser{kind: "a duration", zero: time.Duration(0), parse: func(env string) (interface{}, error) {
+		return time.ParseDuration(env)
+	}}
+	decimalParser = parser{kind: "a decimal", zero: decimal.Zero, parse: func(env string) (interface{}, error) {
+		return decimal.NewFromString(env)
+	}}
+	urlParser = parser{kind: "an absolute URL", zero: (*url.URL)(nil), parse: func(env string) (interface{}, error) {
+		u, err := url.Parse(env)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme == "" {
+			return nil, errors.New("missing scheme")
+		}
+		return u, nil
+	}}
+)
+
+func RequireInt(envName string, description ...string) int {
+	return defaultRegistry.RequireInt(envName, description...)
+}
+
+func (r *Registry) RequireInt(envName string, description ...string) int {
+	return r.requireParsed(envName, intParser, description).(int)
+}
+
+func WarnIfEmptyInt(envName string, description ...string) int {
+	return defaultRegistry.WarnIfEmptyInt(envName, description...)
+}
+
+func (r *Registry) WarnIfEmptyInt(envName string, description ...string) int {
+	return r.warnIfEmptyParsed(envName, intParser, description).(int)
+}
+
+func DefaultInt(envName string, defaultValue int) int {
+	return defaultRegistry.DefaultInt(envName, defaultValue)
+}
+
+func (r *Registry) DefaultInt(envName string, defaultValue int) int {
+	return r.defaultParsed(envName, intParser, defaultValue, nil).(int)
+}
+
+func RequireInt64(envName string, description ...string) int64 {
+	return defaultRegistry.RequireInt64(envName, description...)
+}
+
+func (r *Registry) RequireInt64(envName string, description ...string) int64 {
+	return r.requireParsed(envName, int64Parser, description).(int64)
+}
+
+func WarnIfEmptyInt64(envName string, description ...string) int64 {
+	return defaultRegistry.WarnIfEmptyInt64(envName, description...)
+}
+
+func (r *Registry) WarnIfEmptyInt64(envName string, description ...string) int64 {
+	return r.warnIfEmptyParsed(envName, int64Parser, description).(int64)
+}
+
+func DefaultInt64(envName string, defaultValue int64) int64 {
+	return defaultRegistry.DefaultInt64(envName, defaultValue)
+}
+
+func (r *Registry) DefaultInt64(envName string, defaultValue int64) int64 {
+	return r.defaultParsed(envName, int64Parser, defaultValue, nil).(int64)
+}
+
+func RequireBool(envName string, description ...string) bool {
+	return defaultRegistry.RequireBool(envName, description...)
+}
+
+func (r *Registry) RequireBool(envName string, description ...string) bool {
+	return r.requireParsed(envName, boolParser, description).(bool)
+}
+
+func WarnIfEmptyBool(envName string, description ...string) bool {
+	return defaultRegistry.WarnIfEmptyBool(envName, description...)
+}
+
+func (r *Registry) WarnIfEmptyBool(envName string, description ...string) bool {
+	return r.warnIfEmptyParsed(envName, boolParser, description).(bool)
+}
+
+func DefaultBool(envName string, defaultValue bool) bool {
+	return defaultRegistry.DefaultBool(envName, defaultValue)
+}
+
+func (r *Registry) DefaultBool(envName string, defaultValue bool) bool {
+	return r.defaultParsed(envName, boolParser, defaultValue, nil).(bool)
+}
+
+func RequireFloat64(envName string, description ...string) float64 {
+	return defaultRegistry.RequireFloat64(envName, description...)
+}
+
+func (r *Registry) RequireFloat64(envName string, description ...string) float64 {
+	return r.requireParsed(envName, float64Parser, description).(float64)
+}
+
+func WarnIfEmptyFloat64(envName string, description ...string) float64 {
+	return defaultRegistry.WarnIfEmptyFloat64(envName, description...)
+}
+
+func (r *Registry) WarnIfEmptyFloat64(envName string, description ...string) float64 {
+	return r.warnIfEmptyParsed(envName, float64Parser, description).(float64)
+}
+
+func DefaultFloat64(envName string, defaultValue float64) float64 {
+	return defaultRegistry.DefaultFloat64(envName, defaultValue)
+}
+
+func (r *Registry) DefaultFloat64(envName string, defaultValue float64) float64 {
+	return r.defaultParsed(envName, float64Parser, defaultValue, nil).(float64)
+}
+
+func RequireDuration(envName string, description ...string) time.Duration {
+	return defaultRegistry.RequireDuration(envName, description...)
+}
+
+func (r *Registry) RequireDuration(envName string, description ...string) time.Duration {
+	return r.requireParsed(envName, durationParser, description).(time.Duration)
+}
+
+func WarnIfEmptyDuration(envName string, description ...string) time.Duration {
+	return defaultRegistry.WarnIfEmptyDuration(envName, description...)
+}
+
+func (r *Registry) WarnIfEmptyDuration(envName string, description ...string) time.Duration {
+	return r.warnIfEmptyParsed(envName, durationParser, description).(time.Duration)
+}
+
+func DefaultDuration(envName string, defaultValue time.Duration) time.Duration {
+	return defaultRegistry.DefaultDuration(envName, defaultValue)
+}
+
+func (r *Registry) DefaultDuration(envName string, defaultValue time.Duration) time.Duration {
+	return r.defaultParsed(envName, durationParser, defaultValue, nil).(time.Duration)
+}
+
+func RequireDecimal(envName string, description ...string) decimal.Decimal {
+	return defaultRegistry.RequireDecimal(envName, description...)
+}
+
+func (r *Registry) RequireDecimal(envName string, description ...string) decimal.Decimal {
+	return r.requireParsed(envName, decimalParser, description).(decimal.Decimal)
+}
+
+func WarnIfEmptyDecimal(envName string, description ...string) decimal.Decimal {
+	return defaultRegistry.WarnIfEmptyDecimal(envName, description...)
+}
+
+func (r *Registry) WarnIfEmptyDecimal(envName string, description ...string) decimal.Decimal {
+	return r.warnIfEmptyParsed(envName, decimalParser, description).(decimal.Decimal)
+}
+
+func DefaultDecimal(envName string, defaultValue decimal.Decimal) decimal.Decimal {
+	return defaultRegistry.DefaultDecimal(envName, defaultValue)
+}
+
+func (r *Registry) DefaultDecimal(envName string, defaultValue decimal.Decimal) decimal.Decimal {
+	return r.defaultParsed(envName, decimalParser, defaultValue, nil).(decimal.Decimal)
+}
+
+func RequireURL(envName string, description ...string) *url.URL {
+	return defaultRegistry.RequireURL(envName, description...)
+}
+
+func (r *Registry) RequireURL(envName string, description ...string) *url.URL {
+	return r.requireParsed(envName, urlParser, description).(*url.URL)
+}
+
+func WarnIfEmptyURL(envName string, description ...string) *url.URL {
+	return defaultRegistry.WarnIfEmptyURL(envName, description...)
+}
+
+func (r *Registry) WarnIfEmptyURL(envName string, description ...string) *url.URL {
+	return r.warnIfEmptyParsed(envName, urlParser, description).(*url.URL)
+}
+
+func DefaultURL(envName string, defaultValue *url.URL) *url.URL {
+	return defaultRegistry.DefaultURL(envName, defaultValue)
+}
+
+func (r *Registry) DefaultURL(envName string, defaultValue *url.URL) *url.URL {
+	return r.defaultParsed(envName, urlParser, defaultValue, nil).(*url.URL)
+}
+
+func (r *Registry) requireParsed(envName string, p parser, description []string) interface{} {
+	envName = r.name(envName)
+	v := requiredVariable(envName, description)
+	v.Secret = p.secret
+	env, source, found := r.get(v)
+	if !found {
+		r.prePanic(requiredIssue(envName, description))
+		return p.zero
+	}
+	value, _ := r.parseEnv(envName, env, source, p, description)
+	return value
+}
+
+func (r *Registry) warnIfEmptyParsed(envName string, p parser, description []string) interface{} {
+	envName = r.name(envName)
+	v := optionalVariable(envName, description)
+	v.Secret = p.secret
+	env, source, found := r.get(v)
+	if !found {
+		r.preWarn(emptyIssue(envName, description))
+		return p.zero
+	}
+	value, _ := r.parseEnv(envName, env, source, p, description)
+	return value
+}
+
+func (r *Registry) defaultParsed(envName string, p parser, defaultValue interface{}, description []string) interface{} {
+	envName = r.name(envName)
+	v := defaultVariable(envName, formatDefault(p, defaultValue))
+	if len(description) > 0 {
+		v.Description = description[0]
+	}
+	v.Secret = p.secret
+	env, source, found := r.get(v)
+	if !found {
+		return defaultValue
+	}
+	value, ok := r.parseEnv(envName, env, source, p, description)
+	if !ok {
+		return defaultValue
+	}
+	return value
+}
+
+// parseEnv queues a panicking message instead of returning the error, so a
+// malformed value is reported by Assert together with the missing ones.
+func (r *Registry) parseEnv(envName string, env string, source string, p parser, description []string) (interface{}, bool) {
+	value, err := p.parse(env)
+	if err != nil {
+		issue := invalidIssue(envName, p.kind, description)
+		if e, ok := err.(*elementError); ok {
+			issue.Message = fmt.Sprintf("%s env %s.", envName, e.Error())
+		}
+		issue.Source = source
+		r.prePanic(issue)
+		return p.zero, false
+	}
+	return value, true
+}
@@ -0,0 +1,138 @@
+package env
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRequireTyped(t *testing.T) {
+	init := func() {
+		Reset()
+		_ = os.Setenv("INT_KEY", "8080")
+		_ = os.Setenv("INT64_KEY", "9223372036854775807")
+		_ = os.Setenv("BOOL_KEY", "true")
+		_ = os.Setenv("FLOAT_KEY", "0.75")
+		_ = os.Setenv("DURATION_KEY", "1m30s")
+		_ = os.Setenv("DECIMAL_KEY", "10.25")
+		_ = os.Setenv("URL_KEY", "https://example.com/callback")
+		_ = os.Setenv("INVALID_KEY", "abc")
+	}
+	reset := func() {
+		for _, key := range []string{"INT_KEY", "INT64_KEY", "BOOL_KEY", "FLOAT_KEY", "DURATION_KEY", "DECIMAL_KEY", "URL_KEY", "INVALID_KEY"} {
+			_ = os.Unsetenv(key)
+		}
+		Reset()
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		init()
+		assert.Equal(t, 8080, RequireInt("INT_KEY"))
+		assert.Equal(t, int64(9223372036854775807), RequireInt64("INT64_KEY"))
+		assert.Equal(t, true, RequireBool("BOOL_KEY"))
+		assert.Equal(t, 0.75, RequireFloat64("FLOAT_KEY"))
+		assert.Equal(t, 90*time.Second, RequireDuration("DURATION_KEY"))
+		assert.True(t, decimal.RequireFromString("10.25").Equal(RequireDecimal("DECIMAL_KEY")))
+		assert.Equal(t, "example.com", RequireURL("URL_KEY").Host)
+		assert.Len(t, defaultRegistry.failures, 0)
+		reset()
+	})
+
+	t.Run("Unhappy, env not defined", func(t *testing.T) {
+		init()
+		assert.Equal(t, 0, RequireInt("INT_KEY_2", "listening port"))
+		assert.Nil(t, RequireURL("URL_KEY_2"))
+		assert.Equal(t, defaultRegistry.failures[0].String(), "INT_KEY_2 env is required. (listening port)")
+		assert.Equal(t, defaultRegistry.failures[1].String(), "URL_KEY_2 env is required.")
+		reset()
+	})
+
+	t.Run("Unhappy, env is malformed", func(t *testing.T) {
+		init()
+		assert.Equal(t, 0, RequireInt("INVALID_KEY", "listening port"))
+		assert.Equal(t, false, RequireBool("INVALID_KEY"))
+		assert.Equal(t, time.Duration(0), RequireDuration("INVALID_KEY"))
+		assert.Nil(t, RequireURL("INVALID_KEY"))
+		assert.Equal(t, defaultRegistry.failures[0].String(), "INVALID_KEY env must be an integer. (listening port)")
+		assert.Equal(t, defaultRegistry.failures[1].String(), "INVALID_KEY env must be a boolean.")
+		assert.Equal(t, defaultRegistry.failures[2].String(), "INVALID_KEY env must be a duration.")
+		assert.Equal(t, defaultRegistry.failures[3].String(), "INVALID_KEY env must be an absolute URL.")
+		reset()
+	})
+}
+
+func TestWarnIfEmptyTyped(t *testing.T) {
+	init := func() {
+		Reset()
+		_ = os.Setenv("WARN_INT_KEY", "10")
+		_ = os.Setenv("WARN_INVALID_KEY", "ten")
+	}
+	reset := func() {
+		_ = os.Unsetenv("WARN_INT_KEY")
+		_ = os.Unsetenv("WARN_INVALID_KEY")
+		Reset()
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		init()
+		assert.Equal(t, 10, WarnIfEmptyInt("WARN_INT_KEY"))
+		assert.Len(t, defaultRegistry.warnings, 0)
+		assert.Len(t, defaultRegistry.failures, 0)
+		reset()
+	})
+
+	t.Run("Unhappy, env not defined", func(t *testing.T) {
+		init()
+		assert.Equal(t, int64(0), WarnIfEmptyInt64("WARN_INT_KEY_2", "worker count"))
+		assert.Equal(t, defaultRegistry.warnings[0].String(), "WARN_INT_KEY_2 env is empty, it may be needed. (worker count)")
+		assert.Len(t, defaultRegistry.failures, 0)
+		reset()
+	})
+
+	t.Run("Unhappy, env is malformed", func(t *testing.T) {
+		init()
+		assert.Equal(t, float64(0), WarnIfEmptyFloat64("WARN_INVALID_KEY"))
+		assert.Len(t, defaultRegistry.warnings, 0)
+		assert.Equal(t, defaultRegistry.failures[0].String(), "WARN_INVALID_KEY env must be a number.")
+		reset()
+	})
+}
+
+func TestDefaultTyped(t *testing.T) {
+	init := func() {
+		Reset()
+		_ = os.Setenv("DEFAULT_BOOL_KEY", "false")
+		_ = os.Setenv("DEFAULT_INVALID_KEY", "yes please")
+	}
+	reset := func() {
+		_ = os.Unsetenv("DEFAULT_BOOL_KEY")
+		_ = os.Unsetenv("DEFAULT_INVALID_KEY")
+		Reset()
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		init()
+		assert.Equal(t, false, DefaultBool("DEFAULT_BOOL_KEY", true))
+		reset()
+	})
+
+	t.Run("Happy, env not defined, use default instead", func(t *testing.T) {
+		init()
+		fallback, _ := url.Parse("http://localhost")
+		assert.Equal(t, 5*time.Second, DefaultDuration("DEFAULT_DURATION_KEY", 5*time.Second))
+		assert.Equal(t, fallback, DefaultURL("DEFAULT_URL_KEY", fallback))
+		assert.True(t, decimal.NewFromInt(1).Equal(DefaultDecimal("DEFAULT_DECIMAL_KEY", decimal.NewFromInt(1))))
+		assert.Len(t, defaultRegistry.failures, 0)
+		reset()
+	})
+
+	t.Run("Unhappy, env is malformed, use default and report", func(t *testing.T) {
+		init()
+		assert.Equal(t, true, DefaultBool("DEFAULT_INVALID_KEY", true))
+		assert.Equal(t, defaultRegistry.failures[0].String(), "DEFAULT_INVALID_KEY env must be a boolean.")
+		reset()
+	})
+}
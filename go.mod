@@ -10,4 +10,5 @@ require (
 	github.com/shopspring/decimal v1.2.0
 	github.com/stretchr/testify v1.5.1
 	golang.org/x/text v0.3.7
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -5,6 +5,7 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 )
@@ -38,7 +39,10 @@ func EncryptAES(stringToEncrypt string, keyString string) ([]byte, error) {
 func DecryptAES(encryptedString string, keyString string) (decryptedString *string, err error) {
 
 	key, _ := hex.DecodeString(keyString)
-	enc, _ := hex.DecodeString(encryptedString)
+	enc, err := hex.DecodeString(encryptedString)
+	if err != nil {
+		return nil, err
+	}
 
 	//Create a new Cipher Block from the key
 	block, err := aes.NewCipher(key)
@@ -54,6 +58,9 @@ func DecryptAES(encryptedString string, keyString string) (decryptedString *stri
 
 	//Get the nonce size
 	nonceSize := aesGCM.NonceSize()
+	if len(enc) < nonceSize {
+		return nil, errors.New("encrypted string is too short")
+	}
 
 	//Extract the nonce from the encrypted data
 	nonce, ciphertext := enc[:nonceSize], enc[nonceSize:]
@@ -66,3 +66,21 @@ func TestUtils_EncryptAES(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestUtils_DecryptAES(t *testing.T) {
+	bytes := make([]byte, 32)
+	rand.Read(bytes)
+	key := hex.EncodeToString(bytes)
+
+	t.Run("Failure", func(t *testing.T) {
+		decrypted, err := utils.DecryptAES("abcd", key)
+		assert.Nil(t, decrypted)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		decrypted, err := utils.DecryptAES("not hex", key)
+		assert.Nil(t, decrypted)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"errors"
+)
+
+// Code identifies why a value was rejected, it is stable and safe to match
+// in API clients.
+type Code string
+
+const (
+	CodeInvalidEmail              Code = "invalid_email"
+	CodeInvalidPhoneNumber        Code = "invalid_phone_number"
+	CodeInvalidUUID               Code = "invalid_uuid"
+	CodeInvalidSlug               Code = "invalid_slug"
+	CodeInvalidCurrency           Code = "invalid_currency"
+	CodeInvalidCountry            Code = "invalid_country"
+	CodeInvalidNumeric            Code = "invalid_numeric"
+	CodePasswordMissingLowercase  Code = "password_missing_lowercase"
+	CodePasswordMissingUppercase  Code = "password_missing_uppercase"
+	CodePasswordMissingNumber     Code = "password_missing_number"
+	CodePasswordMissingSymbol     Code = "password_missing_symbol"
+	CodeInvalidBool               Code = "invalid_bool"
+	CodeInvalidDateTime           Code = "invalid_datetime"
+	CodeInvalidPin                Code = "invalid_pin"
+	CodeWeakPin                   Code = "weak_pin"
+	CodeUsernameTooShort          Code = "username_too_short"
+	CodeInvalidUsername           Code = "invalid_username"
+	CodeUsernameRepeatedCharacter Code = "username_repeated_character"
+	CodeInvalidJSON               Code = "invalid_json"
+	CodeInvalidBase64             Code = "invalid_base64"
+	CodeInvalidBase64DataType     Code = "invalid_base64_data_type"
+	CodeInvalidURL                Code = "invalid_url"
+)
+
+// ValidationError describes why a value was rejected: the Code, the Rule
+// that failed, its Params such as a minimum length, and a human Message.
+type ValidationError struct {
+	Code    Code                   `json:"code"`
+	Rule    string                 `json:"rule"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+	Message string                 `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// CodeOf returns the Code of the ValidationError in err, empty when err is
+// not a validation failure.
+func CodeOf(err error) Code {
+	var validationError *ValidationError
+	if errors.As(err, &validationError) {
+		return validationError.Code
+	}
+	return ""
+}
+
+func newValidationError(code Code, rule string, message string, params map[string]interface{}) error {
+	return &ValidationError{Code: code, Rule: rule, Params: params, Message: message}
+}
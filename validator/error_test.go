@@ -0,0 +1,38 @@
+package validator_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/gridwhizth/universe/validator"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		err := validator.ValidateUsername("abc")
+		assert.EqualError(t, err, "must be at least 6 characters")
+		assert.Equal(t, &validator.ValidationError{
+			Code:    validator.CodeUsernameTooShort,
+			Rule:    "username",
+			Params:  map[string]interface{}{"min": 6},
+			Message: "must be at least 6 characters",
+		}, err)
+	})
+
+	t.Run("Happy, json", func(t *testing.T) {
+		data, err := json.Marshal(validator.ValidateEmail("email"))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"code":"invalid_email","rule":"email","message":"must be a valid email address"}`, string(data))
+	})
+
+	t.Run("Happy, code of wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("email: %w", validator.ValidateEmail("email"))
+		assert.Equal(t, validator.CodeInvalidEmail, validator.CodeOf(err))
+	})
+
+	t.Run("Unhappy, not a validation error", func(t *testing.T) {
+		assert.Equal(t, validator.Code(""), validator.CodeOf(nil))
+		assert.Equal(t, validator.Code(""), validator.CodeOf(fmt.Errorf("other")))
+	})
+}
@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"github.com/nyaruka/phonenumbers"
+	"strings"
+)
+
+const (
+	CodeImpossiblePhoneNumber       Code = "impossible_phone_number"
+	CodePhoneNumberRegionNotAllowed Code = "phone_number_region_not_allowed"
+	CodePhoneNumberTypeNotAllowed   Code = "phone_number_type_not_allowed"
+)
+
+// PhoneNumberType is the kind of line a phone number belongs to.
+type PhoneNumberType string
+
+const (
+	PhoneFixedLine         PhoneNumberType = "fixed_line"
+	PhoneMobile            PhoneNumberType = "mobile"
+	PhoneFixedLineOrMobile PhoneNumberType = "fixed_line_or_mobile"
+	PhoneTollFree          PhoneNumberType = "toll_free"
+	PhonePremiumRate       PhoneNumberType = "premium_rate"
+	PhoneSharedCost        PhoneNumberType = "shared_cost"
+	PhoneVoIP              PhoneNumberType = "voip"
+	PhonePersonalNumber    PhoneNumberType = "personal_number"
+	PhonePager             PhoneNumberType = "pager"
+	PhoneUAN               PhoneNumberType = "uan"
+	PhoneVoicemail         PhoneNumberType = "voicemail"
+	PhoneUnknown           PhoneNumberType = "unknown"
+)
+
+var phoneNumberTypes = map[phonenumbers.PhoneNumberType]PhoneNumberType{
+	phonenumbers.FIXED_LINE:           PhoneFixedLine,
+	phonenumbers.MOBILE:               PhoneMobile,
+	phonenumbers.FIXED_LINE_OR_MOBILE: PhoneFixedLineOrMobile,
+	phonenumbers.TOLL_FREE:            PhoneTollFree,
+	phonenumbers.PREMIUM_RATE:         PhonePremiumRate,
+	phonenumbers.SHARED_COST:          PhoneSharedCost,
+	phonenumbers.VOIP:                 PhoneVoIP,
+	phonenumbers.PERSONAL_NUMBER:      PhonePersonalNumber,
+	phonenumbers.PAGER:                PhonePager,
+	phonenumbers.UAN:                  PhoneUAN,
+	phonenumbers.VOICEMAIL:            PhoneVoicemail,
+}
+
+// PhoneNumber is a phone number valid according to the libphonenumber
+// metadata.
+type PhoneNumber struct {
+	// E164 is the number in international format, e.g. +66812345678.
+	E164        string
+	CountryCode int
+	// Region is the ISO 3166 alpha-2 code of the region of the number.
+	Region string
+	Type   PhoneNumberType
+}
+
+// PhonePolicy restricts the phone numbers accepted, an empty Regions or
+// Types accepts any.
+type PhonePolicy struct {
+	// DefaultRegion is the region of the numbers written without the +
+	// international prefix, they are rejected when it is empty.
+	DefaultRegion string
+	Regions       []string
+	Types         []PhoneNumberType
+}
+
+// ThaiMobilePolicy accepts Thai mobile numbers only.
+var ThaiMobilePolicy = PhonePolicy{DefaultRegion: "TH", Regions: []string{"TH"}, Types: []PhoneNumberType{PhoneMobile}}
+
+// ParsePhoneNumber parses a phone number of any region, defaultRegion is
+// the region of a number written without the + international prefix.
+func ParsePhoneNumber(phoneNumber string, defaultRegion string) (PhoneNumber, error) {
+	return PhonePolicy{DefaultRegion: defaultRegion}.Parse(phoneNumber)
+}
+
+func IsValidPhoneNumberForRegion(phoneNumber string, region string) bool {
+	return ValidatePhoneNumberForRegion(phoneNumber, region) == nil
+}
+
+// ValidatePhoneNumberForRegion checks a phone number is valid in region,
+// written in local or international format.
+func ValidatePhoneNumberForRegion(phoneNumber string, region string) error {
+	return PhonePolicy{DefaultRegion: region, Regions: []string{region}}.Validate(phoneNumber)
+}
+
+func (p PhonePolicy) Validate(phoneNumber string) error {
+	_, err := p.Parse(phoneNumber)
+	return err
+}
+
+// Parse parses a phone number and checks it is valid and follows the policy.
+func (p PhonePolicy) Parse(phoneNumber string) (PhoneNumber, error) {
+	invalid := newValidationError(CodeInvalidPhoneNumber, "phonenumber", "must be a valid phone number", nil)
+	number, err := phonenumbers.Parse(phoneNumber, strings.ToUpper(p.DefaultRegion))
+	if err != nil {
+		return PhoneNumber{}, invalid
+	}
+	if !phonenumbers.IsPossibleNumber(number) {
+		return PhoneNumber{}, newValidationError(CodeImpossiblePhoneNumber, "phonenumber",
+			"must have the length of a phone number", nil)
+	}
+	if !phonenumbers.IsValidNumber(number) {
+		return PhoneNumber{}, invalid
+	}
+
+	parsed := PhoneNumber{
+		E164:        phonenumbers.Format(number, phonenumbers.E164),
+		CountryCode: int(number.GetCountryCode()),
+		Region:      phonenumbers.GetRegionCodeForNumber(number),
+		Type:        PhoneUnknown,
+	}
+	if numberType, ok := phoneNumberTypes[phonenumbers.GetNumberType(number)]; ok {
+		parsed.Type = numberType
+	}
+
+	if len(p.Regions) > 0 && !p.allowsRegion(parsed.Region) {
+		return PhoneNumber{}, newValidationError(CodePhoneNumberRegionNotAllowed, "phonenumber",
+			"must be a phone number of "+strings.Join(p.Regions, ", "), map[string]interface{}{"regions": p.Regions})
+	}
+	if len(p.Types) > 0 && !p.allowsType(parsed.Type) {
+		types := make([]string, len(p.Types))
+		for i, t := range p.Types {
+			types[i] = strings.Replace(string(t), "_", " ", -1)
+		}
+		return PhoneNumber{}, newValidationError(CodePhoneNumberTypeNotAllowed, "phonenumber",
+			"must be a "+strings.Join(types, " or ")+" phone number", map[string]interface{}{"types": p.Types})
+	}
+	return parsed, nil
+}
+
+// Rule returns the policy as a Rule to register for validate tags, its
+// errors carry the name of the tag as their rule.
+func (p PhonePolicy) Rule() Rule {
+	return func(value interface{}, _ string) error {
+		err := p.Validate(stringOf(value))
+		if validationError, ok := err.(*ValidationError); ok {
+			untagged := *validationError
+			untagged.Rule = ""
+			return &untagged
+		}
+		return err
+	}
+}
+
+func (p PhonePolicy) allowsRegion(region string) bool {
+	for _, allowed := range p.Regions {
+		if strings.EqualFold(allowed, region) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsType accepts a number that may be a fixed line or a mobile when
+// either is allowed, libphonenumber cannot tell them apart in some regions.
+func (p PhonePolicy) allowsType(numberType PhoneNumberType) bool {
+	for _, allowed := range p.Types {
+		if allowed == numberType ||
+			(numberType == PhoneFixedLineOrMobile && (allowed == PhoneMobile || allowed == PhoneFixedLine)) {
+			return true
+		}
+	}
+	return false
+}
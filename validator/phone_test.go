@@ -0,0 +1,90 @@
+package validator_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/gridwhizth/universe/validator"
+	"testing"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		cases := map[string]validator.PhoneNumber{
+			"081-234-5678":    {E164: "+66812345678", CountryCode: 66, Region: "TH", Type: validator.PhoneMobile},
+			"02 123 4567":     {E164: "+6621234567", CountryCode: 66, Region: "TH", Type: validator.PhoneFixedLine},
+			"1800123456":      {E164: "+661800123456", CountryCode: 66, Region: "TH", Type: validator.PhoneTollFree},
+			"+445612345678":   {E164: "+445612345678", CountryCode: 44, Region: "GB", Type: validator.PhoneVoIP},
+			"+1 201-555-0123": {E164: "+12015550123", CountryCode: 1, Region: "US", Type: validator.PhoneFixedLineOrMobile},
+		}
+		for phoneNumber, expected := range cases {
+			parsed, err := validator.ParsePhoneNumber(phoneNumber, "TH")
+			assert.Nil(t, err, phoneNumber)
+			assert.Equal(t, expected, parsed, phoneNumber)
+		}
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		_, err := validator.ParsePhoneNumber("+0833454345", "TH")
+		assert.Equal(t, validator.CodeInvalidPhoneNumber, validator.CodeOf(err))
+
+		_, err = validator.ParsePhoneNumber("+6621234", "TH")
+		assert.Equal(t, validator.CodeImpossiblePhoneNumber, validator.CodeOf(err))
+
+		_, err = validator.ParsePhoneNumber("0812345678", "")
+		assert.Equal(t, validator.CodeInvalidPhoneNumber, validator.CodeOf(err))
+	})
+}
+
+func TestIsValidPhoneNumberForRegion(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		assert.True(t, validator.IsValidPhoneNumberForRegion("0812345678", "TH"))
+		assert.True(t, validator.IsValidPhoneNumberForRegion("+66 2 123 4567", "th"))
+		assert.True(t, validator.IsValidPhoneNumberForRegion("201-555-0123", "US"))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		assert.False(t, validator.IsValidPhoneNumberForRegion("+0833454345", "TH"))
+		assert.False(t, validator.IsValidPhoneNumberForRegion("+12015550123", "TH"))
+		assert.False(t, validator.IsValidPhoneNumberForRegion("089-1234", "TH"))
+		assert.Equal(t, validator.CodePhoneNumberRegionNotAllowed,
+			validator.CodeOf(validator.ValidatePhoneNumberForRegion("+12015550123", "TH")))
+	})
+}
+
+func TestPhonePolicy(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		assert.Nil(t, validator.ThaiMobilePolicy.Validate("0812345678"))
+		assert.Nil(t, validator.ThaiMobilePolicy.Validate("+66 61 234 5678"))
+
+		usFixed := validator.PhonePolicy{Regions: []string{"US"}, Types: []validator.PhoneNumberType{validator.PhoneFixedLine}}
+		assert.Nil(t, usFixed.Validate("+12015550123"))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		err := validator.ThaiMobilePolicy.Validate("021234567")
+		assert.Equal(t, validator.CodePhoneNumberTypeNotAllowed, validator.CodeOf(err))
+		assert.EqualError(t, err, "must be a mobile phone number")
+
+		err = validator.ThaiMobilePolicy.Validate("+445612345678")
+		assert.EqualError(t, err, "must be a phone number of TH")
+
+		tollFree := validator.PhonePolicy{Types: []validator.PhoneNumberType{validator.PhoneTollFree}}
+		assert.NotNil(t, tollFree.Validate("+12015550123"))
+	})
+}
+
+func TestStruct_PhoneNumber(t *testing.T) {
+	type contact struct {
+		Phone  string `json:"phone" validate:"phonenumber=TH"`
+		Mobile string `json:"mobile" validate:"thaimobile"`
+		Global string `json:"global" validate:"phonenumber"`
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		assert.Nil(t, validator.Struct(contact{Phone: "021234567", Mobile: "0812345678", Global: "+445612345678"}))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		assert.EqualError(t, validator.Struct(contact{Phone: "+12015550123", Mobile: "021234567", Global: "0812345678"}),
+			"phone must be a phone number of TH; mobile must be a mobile phone number; global must be a valid phone number")
+	})
+}
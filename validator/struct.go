@@ -0,0 +1,383 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Rule checks a field value against the parameter written after = in the
+// tag, e.g. 3 for len=3. value is never a pointer.
+type Rule func(value interface{}, param string) error
+
+// FieldError is a ValidationError of the field at the JSON path Field.
+type FieldError struct {
+	Field string `json:"field"`
+	*ValidationError
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// FieldErrors are all the field errors of a struct, in field order.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+const (
+	CodeRequired      Code = "required"
+	CodeInvalidLength Code = "invalid_length"
+	CodeTooSmall      Code = "too_small"
+	CodeTooLarge      Code = "too_large"
+	CodeNotOneOf      Code = "not_one_of"
+)
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]Rule{
+		"email":          stringRule(ValidateEmail),
+		"phone":          stringRule(ValidatePhoneNumber),
+		"uuid":           stringRule(ValidateUUID),
+		"slug":           stringRule(ValidateSlug),
+		"currency":       stringRule(ValidateCurrency),
+		"country":        stringRule(ValidateCountry),
+		"numeric":        stringRule(ValidateNumericFromString),
+		"password":       stringRule(ValidatePassword),
+		"bool":           stringRule(ValidateBoolFromString),
+		"pin":            stringRule(ValidatePin6Digit),
+		"username":       stringRule(ValidateUsername),
+		"json":           stringRule(ValidateJSON),
+		"base64":         stringRule(ValidateBase64),
+		"base64datatype": stringRule(ValidateBase64DataType),
+		"url":            stringRule(ValidateURL),
+		"thainationalid": stringRule(ValidateThaiNationalID),
+		"thaitaxid":      stringRule(ValidateThaiTaxID),
+		"thaijuristicid": stringRule(ValidateThaiJuristicID),
+		"datetime": func(value interface{}, param string) error {
+			return ValidateDateTimeFromString(param, stringOf(value))
+		},
+		"phonenumber": func(value interface{}, param string) error {
+			if param == "" {
+				_, err := ParsePhoneNumber(stringOf(value), "")
+				return err
+			}
+			return ValidatePhoneNumberForRegion(stringOf(value), param)
+		},
+		"thaimobile": ThaiMobilePolicy.Rule(),
+		"oneof": func(value interface{}, param string) error {
+			options := strings.Fields(param)
+			for _, option := range options {
+				if fmt.Sprint(value) == option {
+					return nil
+				}
+			}
+			return newValidationError(CodeNotOneOf, "oneof", "must be one of "+strings.Join(options, ", "),
+				map[string]interface{}{"options": options})
+		},
+	}
+)
+
+// RegisterRule adds a rule usable in validate tags, replacing any rule of
+// the same name. An error not being a *ValidationError is reported with the
+// code invalid_<name>.
+func RegisterRule(name string, rule Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[name] = rule
+}
+
+// Struct validates the fields of a struct, or pointer to struct, following
+// their validate tags and returns every failure as FieldErrors.
+//
+//	Email   string   `json:"email" validate:"required,email"`
+//	Country *string  `json:"country" validate:"country,len=3"`
+//	Tags    []string `json:"tags" validate:"max=5,slug"`
+//
+// required rejects a nil pointer, or an empty or zero value for other
+// kinds. The other rules skip a nil pointer and an empty string, slice or
+// map, they check numbers and other values even when zero unless the
+// omitempty option skips zero values. len, min and max check the length of
+// strings, slices and maps and the value of numbers, the other rules check
+// each element of a slice or map. Nested structs, in fields, slices or maps,
+// are always validated. A nil pointer to a struct has no field to check and
+// is valid, like an optional pointer field. Unknown rules, malformed params
+// and a v that is not a struct panic.
+func Struct(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			if indirectType(value.Type()).Kind() != reflect.Struct {
+				panic(fmt.Sprintf("validator: Struct expects a struct, got %T", v))
+			}
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: Struct expects a struct, got %T", v))
+	}
+
+	var errs FieldErrors
+	validateStruct(value, "", &errs, map[visit]bool{})
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// visit is a struct reached through a pointer, validated once to stop on
+// cyclic graphs.
+type visit struct {
+	address uintptr
+	typ     reflect.Type
+}
+
+func validateStruct(value reflect.Value, path string, errs *FieldErrors, visited map[visit]bool) {
+	structType := value.Type()
+	if value.CanAddr() {
+		key := visit{address: value.UnsafeAddr(), typ: structType}
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		embedded := field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct
+		if field.PkgPath != "" && !embedded {
+			continue
+		}
+
+		name := jsonName(field)
+		fieldPath := path
+		if name != "" {
+			fieldPath = joinPath(path, name)
+		} else if !embedded {
+			fieldPath = joinPath(path, field.Name)
+		}
+		validateField(value.Field(i), fieldPath, field.Tag.Get("validate"), errs, visited)
+	}
+}
+
+func validateField(value reflect.Value, path string, tag string, errs *FieldErrors, visited map[visit]bool) {
+	isPointer := value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface
+	value = indirect(value)
+	missing := !value.IsValid() || (!isPointer && isEmpty(value))
+	skip := !value.IsValid() || (!isPointer && hasLength(value) && value.Len() == 0)
+	for _, rule := range strings.Split(tag, ",") {
+		if rule == "omitempty" {
+			skip = skip || missing
+		}
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, param := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			name, param = rule[:i], rule[i+1:]
+		}
+
+		switch name {
+		case "", "omitempty":
+		case "required":
+			if missing {
+				*errs = append(*errs, &FieldError{Field: path, ValidationError: &ValidationError{
+					Code: CodeRequired, Rule: "required", Message: "is required"}})
+				return
+			}
+		case "len", "min", "max":
+			if !skip {
+				if err := checkSize(value, name, param); err != nil {
+					*errs = append(*errs, &FieldError{Field: path, ValidationError: err})
+				}
+			}
+		default:
+			if !skip {
+				applyRule(value, path, name, param, errs)
+			}
+		}
+	}
+
+	if value.IsValid() {
+		validateNested(value, path, errs, visited)
+	}
+}
+
+// applyRule checks value, or each element of a slice or map, with the rule.
+func applyRule(value reflect.Value, path string, name string, param string, errs *FieldErrors) {
+	rulesMu.RLock()
+	rule, ok := rules[name]
+	rulesMu.RUnlock()
+	if !ok {
+		panic(fmt.Sprintf("validator: unknown rule %q on %s", name, path))
+	}
+
+	check := func(value reflect.Value, path string) {
+		value = indirect(value)
+		if !value.IsValid() {
+			return
+		}
+		if err := rule(value.Interface(), param); err != nil {
+			*errs = append(*errs, &FieldError{Field: path, ValidationError: asValidationError(name, err)})
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			check(value.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(value) {
+			check(value.MapIndex(key), fmt.Sprintf("%s[%v]", path, key.Interface()))
+		}
+	default:
+		check(value, path)
+	}
+}
+
+func validateNested(value reflect.Value, path string, errs *FieldErrors, visited map[visit]bool) {
+	switch value.Kind() {
+	case reflect.Struct:
+		validateStruct(value, path, errs, visited)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if element := indirect(value.Index(i)); element.Kind() == reflect.Struct {
+				validateStruct(element, fmt.Sprintf("%s[%d]", path, i), errs, visited)
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(value) {
+			if element := indirect(value.MapIndex(key)); element.Kind() == reflect.Struct {
+				validateStruct(element, fmt.Sprintf("%s[%v]", path, key.Interface()), errs, visited)
+			}
+		}
+	}
+}
+
+func checkSize(value reflect.Value, rule string, param string) *ValidationError {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validator: %s expects a number, got %q", rule, param))
+	}
+
+	var size float64
+	subject := "have a length of"
+	switch value.Kind() {
+	case reflect.String:
+		size = float64(utf8.RuneCountInString(value.String()))
+	case reflect.Slice, reflect.Array, reflect.Map:
+		size = float64(value.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size, subject = float64(value.Int()), "be"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		size, subject = float64(value.Uint()), "be"
+	case reflect.Float32, reflect.Float64:
+		size, subject = value.Float(), "be"
+	default:
+		panic(fmt.Sprintf("validator: %s does not apply to %s", rule, value.Type()))
+	}
+
+	params := map[string]interface{}{rule: limit}
+	switch {
+	case rule == "len" && size != limit:
+		return &ValidationError{Code: CodeInvalidLength, Rule: rule, Params: params,
+			Message: fmt.Sprintf("must have a length of %s", param)}
+	case rule == "min" && size < limit:
+		return &ValidationError{Code: CodeTooSmall, Rule: rule, Params: params,
+			Message: fmt.Sprintf("must %s at least %s", subject, param)}
+	case rule == "max" && size > limit:
+		return &ValidationError{Code: CodeTooLarge, Rule: rule, Params: params,
+			Message: fmt.Sprintf("must %s at most %s", subject, param)}
+	}
+	return nil
+}
+
+func asValidationError(rule string, err error) *ValidationError {
+	if validationError, ok := err.(*ValidationError); ok {
+		if validationError.Rule != "" {
+			return validationError
+		}
+		copied := *validationError
+		copied.Rule = rule
+		return &copied
+	}
+	return &ValidationError{Code: Code("invalid_" + rule), Rule: rule, Message: err.Error()}
+}
+
+func stringRule(validate func(string) error) Rule {
+	return func(value interface{}, _ string) error {
+		return validate(stringOf(value))
+	}
+}
+
+func stringOf(value interface{}) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.String {
+		panic(fmt.Sprintf("validator: rule expects a string, got %T", value))
+	}
+	return v.String()
+}
+
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func hasLength(value reflect.Value) bool {
+	kind := value.Kind()
+	return kind == reflect.String || kind == reflect.Slice || kind == reflect.Map
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
@@ -0,0 +1,247 @@
+package validator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/gridwhizth/universe/pointer"
+	"gitlab.com/gridwhizth/universe/validator"
+	"strings"
+	"testing"
+)
+
+type address struct {
+	Country string `json:"country" validate:"required,country,len=3"`
+	Zip     string `json:"zip" validate:"numeric"`
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by" validate:"required"`
+}
+
+type customer struct {
+	Audit
+	Email     string             `json:"email" validate:"required,email"`
+	Website   *string            `json:"website,omitempty" validate:"url"`
+	Age       *int               `json:"age" validate:"required,min=18,max=130"`
+	Currency  string             `json:"currency" validate:"oneof=THB USD"`
+	Tags      []string           `json:"tags" validate:"max=2,slug"`
+	Addresses []*address         `json:"addresses" validate:"required,min=1"`
+	Contacts  map[string]address `json:"contacts"`
+	Billing   *address           `json:"billing"`
+	Note      string             `validate:"max=5"`
+	internal  string             `validate:"required"`
+}
+
+func validCustomer() customer {
+	return customer{
+		Audit:     Audit{CreatedBy: "admin"},
+		Email:     "example@gmail.com",
+		Website:   pointer.ToString("https://example.com"),
+		Age:       pointer.ToInt(30),
+		Currency:  "THB",
+		Tags:      []string{"vip", "early-bird"},
+		Addresses: []*address{{Country: "THA", Zip: "10110"}},
+		Contacts:  map[string]address{"home": {Country: "USA"}},
+	}
+}
+
+func fieldErrors(t *testing.T, err error) map[string]validator.Code {
+	var errs validator.FieldErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected FieldErrors, got %v", err)
+	}
+	codes := map[string]validator.Code{}
+	for _, err := range errs {
+		codes[err.Field] = err.Code
+	}
+	return codes
+}
+
+func TestStruct(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		c := validCustomer()
+		assert.Nil(t, validator.Struct(c))
+		assert.Nil(t, validator.Struct(&c))
+	})
+
+	t.Run("Happy, optional fields skipped when empty", func(t *testing.T) {
+		c := validCustomer()
+		c.Website = nil
+		c.Currency = ""
+		c.Tags = nil
+		c.Contacts = nil
+		assert.Nil(t, validator.Struct(c))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		c := customer{
+			Website:   pointer.ToString(""),
+			Age:       pointer.ToInt(12),
+			Currency:  "EUR",
+			Tags:      []string{"ok", "Not-A-Slug", "third"},
+			Addresses: []*address{{Country: "TH", Zip: "abc"}, nil},
+			Contacts:  map[string]address{"work": {}},
+			Billing:   &address{Country: "XYZ"},
+			Note:      "too long",
+		}
+		assert.Equal(t, map[string]validator.Code{
+			"created_by":             validator.CodeRequired,
+			"email":                  validator.CodeRequired,
+			"website":                validator.CodeInvalidURL,
+			"age":                    validator.CodeTooSmall,
+			"currency":               validator.CodeNotOneOf,
+			"tags":                   validator.CodeTooLarge,
+			"tags[1]":                validator.CodeInvalidSlug,
+			"addresses[0].country":   validator.CodeInvalidLength,
+			"addresses[0].zip":       validator.CodeInvalidNumeric,
+			"contacts[work].country": validator.CodeRequired,
+			"billing.country":        validator.CodeInvalidCountry,
+			"Note":                   validator.CodeTooLarge,
+		}, fieldErrors(t, validator.Struct(c)))
+	})
+
+	t.Run("Happy, nil pointer", func(t *testing.T) {
+		var c *customer
+		assert.Nil(t, validator.Struct(c))
+		assert.Nil(t, validator.Struct(&c))
+	})
+
+	t.Run("Unhappy, messages", func(t *testing.T) {
+		c := validCustomer()
+		c.Email = "email"
+		c.Age = pointer.ToInt(200)
+		c.Addresses = nil
+
+		err := validator.Struct(c)
+		assert.EqualError(t, err, "email must be a valid email address; age must be at most 130; addresses is required")
+
+		var errs validator.FieldErrors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, "max", errs[1].Rule)
+		assert.Equal(t, map[string]interface{}{"max": float64(130)}, errs[1].Params)
+
+		data, _ := json.Marshal(errs[0])
+		assert.JSONEq(t, `{"field":"email","code":"invalid_email","rule":"email","message":"must be a valid email address"}`, string(data))
+	})
+
+	t.Run("Unhappy, length messages", func(t *testing.T) {
+		c := validCustomer()
+		c.Addresses = []*address{}
+		c.Note = "longer"
+		assert.EqualError(t, validator.Struct(c), "addresses is required; Note must have a length of at most 5")
+	})
+
+	t.Run("Unhappy, unknown rule", func(t *testing.T) {
+		v := struct {
+			Name string `validate:"unknown"`
+		}{Name: "name"}
+		assert.PanicsWithValue(t, `validator: unknown rule "unknown" on Name`, func() { _ = validator.Struct(v) })
+	})
+
+	t.Run("Unhappy, not a struct", func(t *testing.T) {
+		assert.Panics(t, func() { _ = validator.Struct("string") })
+		assert.Panics(t, func() { _ = validator.Struct((*string)(nil)) })
+		assert.Panics(t, func() { _ = validator.Struct(nil) })
+	})
+}
+
+func TestStruct_PhoneNumberRules(t *testing.T) {
+	type contact struct {
+		Phone  string `json:"phone" validate:"phonenumber=TH"`
+		Mobile string `json:"mobile" validate:"thaimobile"`
+	}
+
+	t.Run("Unhappy", func(t *testing.T) {
+		errs := validator.Struct(contact{Phone: "+12015550123", Mobile: "021234567"}).(validator.FieldErrors)
+		assert.Equal(t, "phonenumber", errs[0].Rule)
+		assert.Equal(t, validator.CodePhoneNumberRegionNotAllowed, errs[0].Code)
+		assert.Equal(t, "thaimobile", errs[1].Rule)
+		assert.Equal(t, validator.CodePhoneNumberTypeNotAllowed, errs[1].Code)
+	})
+
+	t.Run("Unhappy, policy outside a tag", func(t *testing.T) {
+		err := validator.ThaiMobilePolicy.Validate("021234567")
+		assert.Equal(t, "phonenumber", err.(*validator.ValidationError).Rule)
+	})
+}
+
+type node struct {
+	Name     string           `json:"name" validate:"required"`
+	Next     *node            `json:"next"`
+	Children []*node          `json:"children"`
+	Links    map[string]*node `json:"links"`
+}
+
+func TestStruct_Zero(t *testing.T) {
+	type account struct {
+		Age      int     `json:"age" validate:"min=18"`
+		Balance  float64 `json:"balance" validate:"omitempty,min=100"`
+		Quantity *int    `json:"quantity" validate:"min=1"`
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		assert.Nil(t, validator.Struct(account{Age: 18}))
+	})
+
+	t.Run("Unhappy, zero numbers are checked", func(t *testing.T) {
+		assert.EqualError(t, validator.Struct(account{Quantity: pointer.ToInt(0)}),
+			"age must be at least 18; quantity must be at least 1")
+	})
+
+	t.Run("Unhappy, omitempty only skips zero values", func(t *testing.T) {
+		assert.EqualError(t, validator.Struct(account{Age: 18, Balance: 50}), "balance must be at least 100")
+	})
+}
+
+func TestStruct_Cycles(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		n := &node{Name: "root"}
+		n.Next = n
+		n.Children = []*node{n}
+		n.Links = map[string]*node{"self": n}
+		assert.Nil(t, validator.Struct(n))
+	})
+
+	t.Run("Unhappy, each node reported once", func(t *testing.T) {
+		first := &node{}
+		second := &node{Next: first}
+		first.Next = second
+		assert.EqualError(t, validator.Struct(first), "name is required; next.name is required")
+	})
+}
+
+func TestRegisterRule(t *testing.T) {
+	validator.RegisterRule("prefix", func(value interface{}, param string) error {
+		if !strings.HasPrefix(value.(string), param) {
+			return errors.New("must start with " + param)
+		}
+		return nil
+	})
+	validator.RegisterRule("even", func(value interface{}, _ string) error {
+		if value.(int)%2 != 0 {
+			return &validator.ValidationError{Code: "odd", Message: "must be even"}
+		}
+		return nil
+	})
+
+	type order struct {
+		Reference string `json:"reference" validate:"prefix=ORD-"`
+		Quantity  int    `json:"quantity" validate:"even"`
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		assert.Nil(t, validator.Struct(order{Reference: "ORD-1", Quantity: 2}))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		err := validator.Struct(order{Reference: "1", Quantity: 3})
+		assert.EqualError(t, err, "reference must start with ORD-; quantity must be even")
+
+		errs := err.(validator.FieldErrors)
+		assert.Equal(t, validator.Code("invalid_prefix"), errs[0].Code)
+		assert.Equal(t, "prefix", errs[0].Rule)
+		assert.Equal(t, validator.Code("odd"), errs[1].Code)
+		assert.Equal(t, "even", errs[1].Rule)
+	})
+}
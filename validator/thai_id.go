@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"strings"
+)
+
+const (
+	CodeInvalidThaiNationalID         Code = "invalid_thai_national_id"
+	CodeInvalidThaiNationalIDChecksum Code = "invalid_thai_national_id_checksum"
+)
+
+// ThaiNationalID is a 13-digit Thai citizen ID card number.
+type ThaiNationalID struct {
+	digits string
+}
+
+func IsValidThaiNationalID(id string) bool {
+	return ValidateThaiNationalID(id) == nil
+}
+
+// ValidateThaiNationalID checks the 13 digits and mod-11 checksum of a Thai
+// national ID, digits may be separated by dashes or spaces.
+func ValidateThaiNationalID(id string) error {
+	_, err := ParseThaiNationalID(id)
+	return err
+}
+
+func ParseThaiNationalID(id string) (ThaiNationalID, error) {
+	digits, ok := thaiIDDigits(id)
+	if !ok {
+		return ThaiNationalID{}, newValidationError(CodeInvalidThaiNationalID, "thainationalid",
+			"must be a 13-digit Thai national ID", map[string]interface{}{"length": 13})
+	}
+	if !validThaiIDChecksum(digits) {
+		return ThaiNationalID{}, newValidationError(CodeInvalidThaiNationalIDChecksum, "thainationalid",
+			"must be a Thai national ID with a valid check digit", nil)
+	}
+	return ThaiNationalID{digits: digits}, nil
+}
+
+// FormatThaiNationalID renders a valid ID in the dashed form 1-2345-67890-12-3.
+func FormatThaiNationalID(id string) (string, error) {
+	parsed, err := ParseThaiNationalID(id)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// Category is the first digit, the type of person registered, e.g. 1 for a
+// Thai national whose birth was registered in time.
+func (id ThaiNationalID) Category() int {
+	if id.digits == "" {
+		return 0
+	}
+	return int(id.digits[0] - '0')
+}
+
+// ProvinceCode is the code of the province that registered the person.
+func (id ThaiNationalID) ProvinceCode() string {
+	if id.digits == "" {
+		return ""
+	}
+	return id.digits[1:3]
+}
+
+// OfficeCode is the code of the registration office, the province code
+// followed by the district.
+func (id ThaiNationalID) OfficeCode() string {
+	if id.digits == "" {
+		return ""
+	}
+	return id.digits[1:5]
+}
+
+// Digits returns the 13 digits without separators.
+func (id ThaiNationalID) Digits() string {
+	return id.digits
+}
+
+func (id ThaiNationalID) String() string {
+	if id.digits == "" {
+		return ""
+	}
+	return formatThaiID(id.digits)
+}
+
+// thaiIDDigits strips the dashes and spaces of a 13-digit Thai ID.
+func thaiIDDigits(id string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(id))
+	if len(digits) != 13 {
+		return "", false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return digits, true
+}
+
+// validThaiIDChecksum checks the last digit is the mod-11 checksum of the
+// first 12 weighted from 13 down to 2.
+func validThaiIDChecksum(digits string) bool {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		sum += int(digits[i]-'0') * (13 - i)
+	}
+	return (11-sum%11)%10 == int(digits[12]-'0')
+}
+
+func formatThaiID(digits string) string {
+	return digits[0:1] + "-" + digits[1:5] + "-" + digits[5:10] + "-" + digits[10:12] + "-" + digits[12:13]
+}
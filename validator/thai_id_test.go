@@ -0,0 +1,96 @@
+package validator_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/gridwhizth/universe/validator"
+	"testing"
+)
+
+func TestIsValidThaiNationalID(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		assert.True(t, validator.IsValidThaiNationalID("1101700203115"))
+		assert.True(t, validator.IsValidThaiNationalID("1-2345-67890-12-1"))
+		assert.True(t, validator.IsValidThaiNationalID("3 1001 00123 45 1"))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		assert.False(t, validator.IsValidThaiNationalID(""))
+		assert.False(t, validator.IsValidThaiNationalID("1101700203116"))
+		assert.False(t, validator.IsValidThaiNationalID("110170020311"))
+		assert.False(t, validator.IsValidThaiNationalID("11017002031150"))
+		assert.False(t, validator.IsValidThaiNationalID("1-2345-6789O-12-1"))
+		assert.False(t, validator.IsValidThaiNationalID("1/2345/67890/12/1"))
+	})
+}
+
+func TestValidateThaiNationalID(t *testing.T) {
+	t.Run("Unhappy, format", func(t *testing.T) {
+		err := validator.ValidateThaiNationalID("12345")
+		assert.Equal(t, validator.CodeInvalidThaiNationalID, validator.CodeOf(err))
+		assert.EqualError(t, err, "must be a 13-digit Thai national ID")
+	})
+
+	t.Run("Unhappy, checksum", func(t *testing.T) {
+		err := validator.ValidateThaiNationalID("1-2345-67890-12-3")
+		assert.Equal(t, validator.CodeInvalidThaiNationalIDChecksum, validator.CodeOf(err))
+	})
+}
+
+func TestParseThaiNationalID(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		id, err := validator.ParseThaiNationalID("3 1001 00123 45 1")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, id.Category())
+		assert.Equal(t, "10", id.ProvinceCode())
+		assert.Equal(t, "1001", id.OfficeCode())
+		assert.Equal(t, "3100100123451", id.Digits())
+		assert.Equal(t, "3-1001-00123-45-1", id.String())
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		id, err := validator.ParseThaiNationalID("3100100123450")
+		assert.NotNil(t, err)
+		assert.Equal(t, "", id.String())
+	})
+
+	t.Run("Unhappy, zero value", func(t *testing.T) {
+		var id validator.ThaiNationalID
+		assert.Equal(t, 0, id.Category())
+		assert.Equal(t, "", id.ProvinceCode())
+		assert.Equal(t, "", id.OfficeCode())
+		assert.Equal(t, "", id.Digits())
+		assert.Equal(t, "", id.String())
+
+		id, _ = validator.ParseThaiNationalID("invalid")
+		assert.Equal(t, 0, id.Category())
+		assert.Equal(t, "", id.OfficeCode())
+	})
+}
+
+func TestFormatThaiNationalID(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		formatted, err := validator.FormatThaiNationalID("1101700203115")
+		assert.Nil(t, err)
+		assert.Equal(t, "1-1017-00203-11-5", formatted)
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		_, err := validator.FormatThaiNationalID("1101700203110")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestStruct_ThaiNationalID(t *testing.T) {
+	type citizen struct {
+		ID string `json:"id" validate:"required,thainationalid"`
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		assert.Nil(t, validator.Struct(citizen{ID: "1-1017-00203-11-5"}))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		assert.EqualError(t, validator.Struct(citizen{ID: "1-1017-00203-11-4"}),
+			"id must be a Thai national ID with a valid check digit")
+	})
+}
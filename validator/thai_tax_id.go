@@ -0,0 +1,138 @@
+package validator
+
+const (
+	CodeInvalidThaiTaxID         Code = "invalid_thai_tax_id"
+	CodeInvalidThaiTaxIDChecksum Code = "invalid_thai_tax_id_checksum"
+	CodeNotThaiJuristicID        Code = "not_thai_juristic_id"
+)
+
+// EntityCategory is the kind of taxpayer identified by a Thai tax ID.
+type EntityCategory string
+
+const (
+	// EntityIndividual is a person, whose tax ID is the national ID.
+	EntityIndividual EntityCategory = "individual"
+	// EntityOrdinaryPartnership is a registered ordinary partnership.
+	EntityOrdinaryPartnership EntityCategory = "ordinary_partnership"
+	// EntityLimitedPartnership is a limited partnership.
+	EntityLimitedPartnership EntityCategory = "limited_partnership"
+	// EntityLimitedCompany is a private limited company.
+	EntityLimitedCompany EntityCategory = "limited_company"
+	// EntityPublicLimitedCompany is a public limited company.
+	EntityPublicLimitedCompany EntityCategory = "public_limited_company"
+	// EntityRevenueDepartment is an entity registered by the Revenue
+	// Department rather than the Department of Business Development, such
+	// as a foundation, an association or a foreign company.
+	EntityRevenueDepartment EntityCategory = "revenue_department"
+	// EntityOtherJuristic is a juristic person of another registered type.
+	EntityOtherJuristic EntityCategory = "other_juristic"
+)
+
+// revenueDepartmentOffice is the office code of the tax IDs issued by the
+// Revenue Department.
+const revenueDepartmentOffice = "99"
+
+var juristicTypes = map[byte]EntityCategory{
+	'1': EntityOrdinaryPartnership,
+	'2': EntityLimitedPartnership,
+	'5': EntityLimitedCompany,
+	'7': EntityPublicLimitedCompany,
+}
+
+// ThaiTaxID is a 13-digit Thai tax identification number: the national ID
+// of an individual, or the registration number of a juristic person
+// starting with 0.
+type ThaiTaxID struct {
+	digits string
+}
+
+func IsValidThaiTaxID(id string) bool {
+	return ValidateThaiTaxID(id) == nil
+}
+
+// ValidateThaiTaxID checks the 13 digits and mod-11 checksum of the tax ID
+// of an individual or a juristic person.
+func ValidateThaiTaxID(id string) error {
+	_, err := ParseThaiTaxID(id)
+	return err
+}
+
+func IsValidThaiJuristicID(id string) bool {
+	return ValidateThaiJuristicID(id) == nil
+}
+
+// ValidateThaiJuristicID checks a tax ID identifies a juristic person.
+func ValidateThaiJuristicID(id string) error {
+	_, err := ParseThaiJuristicID(id)
+	return err
+}
+
+func ParseThaiTaxID(id string) (ThaiTaxID, error) {
+	digits, ok := thaiIDDigits(id)
+	if !ok {
+		return ThaiTaxID{}, newValidationError(CodeInvalidThaiTaxID, "thaitaxid",
+			"must be a 13-digit Thai tax ID", map[string]interface{}{"length": 13})
+	}
+	if !validThaiIDChecksum(digits) {
+		return ThaiTaxID{}, newValidationError(CodeInvalidThaiTaxIDChecksum, "thaitaxid",
+			"must be a Thai tax ID with a valid check digit", nil)
+	}
+	return ThaiTaxID{digits: digits}, nil
+}
+
+// ParseThaiJuristicID parses the tax ID of a juristic person, rejecting the
+// one of an individual.
+func ParseThaiJuristicID(id string) (ThaiTaxID, error) {
+	parsed, err := ParseThaiTaxID(id)
+	if err != nil {
+		return ThaiTaxID{}, err
+	}
+	if !parsed.IsJuristic() {
+		return ThaiTaxID{}, newValidationError(CodeNotThaiJuristicID, "thaijuristicid",
+			"must be the tax ID of a juristic person", nil)
+	}
+	return parsed, nil
+}
+
+func (id ThaiTaxID) IsJuristic() bool {
+	return id.digits != "" && id.digits[0] == '0'
+}
+
+// Category is the kind of entity, read from the office code and the type
+// digit of a juristic person registration number, empty for the zero value.
+func (id ThaiTaxID) Category() EntityCategory {
+	if id.digits == "" {
+		return ""
+	}
+	if !id.IsJuristic() {
+		return EntityIndividual
+	}
+	if id.OfficeCode() == revenueDepartmentOffice {
+		return EntityRevenueDepartment
+	}
+	if category, ok := juristicTypes[id.digits[3]]; ok {
+		return category
+	}
+	return EntityOtherJuristic
+}
+
+// OfficeCode is the code of the office that registered a juristic person,
+// 10 for Bangkok, empty for an individual.
+func (id ThaiTaxID) OfficeCode() string {
+	if !id.IsJuristic() {
+		return ""
+	}
+	return id.digits[1:3]
+}
+
+// Digits returns the 13 digits without separators.
+func (id ThaiTaxID) Digits() string {
+	return id.digits
+}
+
+func (id ThaiTaxID) String() string {
+	if id.digits == "" {
+		return ""
+	}
+	return formatThaiID(id.digits)
+}
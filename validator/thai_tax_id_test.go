@@ -0,0 +1,111 @@
+package validator_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/gridwhizth/universe/validator"
+	"testing"
+)
+
+func TestIsValidThaiTaxID(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		assert.True(t, validator.IsValidThaiTaxID("0107537000009"))
+		assert.True(t, validator.IsValidThaiTaxID("0-1055-36123-45-8"))
+		assert.True(t, validator.IsValidThaiTaxID("1101700203115"))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		assert.False(t, validator.IsValidThaiTaxID(""))
+		assert.False(t, validator.IsValidThaiTaxID("0107537000008"))
+		assert.False(t, validator.IsValidThaiTaxID("010753700000"))
+		assert.False(t, validator.IsValidThaiTaxID("01075370000a9"))
+	})
+}
+
+func TestIsValidThaiJuristicID(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		assert.True(t, validator.IsValidThaiJuristicID("0107537000009"))
+		assert.True(t, validator.IsValidThaiJuristicID("0994000123451"))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		assert.False(t, validator.IsValidThaiJuristicID("1101700203115"))
+		assert.False(t, validator.IsValidThaiJuristicID("0107537000008"))
+	})
+
+	t.Run("Unhappy, codes", func(t *testing.T) {
+		assert.Equal(t, validator.CodeNotThaiJuristicID, validator.CodeOf(validator.ValidateThaiJuristicID("1101700203115")))
+		assert.Equal(t, validator.CodeInvalidThaiTaxIDChecksum, validator.CodeOf(validator.ValidateThaiJuristicID("0107537000008")))
+		assert.Equal(t, validator.CodeInvalidThaiTaxID, validator.CodeOf(validator.ValidateThaiJuristicID("0107")))
+	})
+}
+
+func TestParseThaiTaxID(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		cases := map[string]validator.EntityCategory{
+			"1101700203115": validator.EntityIndividual,
+			"0101551001232": validator.EntityOrdinaryPartnership,
+			"0102530000017": validator.EntityLimitedPartnership,
+			"0105536123458": validator.EntityLimitedCompany,
+			"0107537000009": validator.EntityPublicLimitedCompany,
+			"0994000123451": validator.EntityRevenueDepartment,
+			"0109530000013": validator.EntityOtherJuristic,
+		}
+		for id, category := range cases {
+			parsed, err := validator.ParseThaiTaxID(id)
+			assert.Nil(t, err)
+			assert.Equal(t, category, parsed.Category(), id)
+		}
+	})
+
+	t.Run("Happy, juristic", func(t *testing.T) {
+		id, err := validator.ParseThaiJuristicID("0 1055 36123 45 8")
+		assert.Nil(t, err)
+		assert.True(t, id.IsJuristic())
+		assert.Equal(t, "10", id.OfficeCode())
+		assert.Equal(t, "0105536123458", id.Digits())
+		assert.Equal(t, "0-1055-36123-45-8", id.String())
+	})
+
+	t.Run("Happy, individual", func(t *testing.T) {
+		id, err := validator.ParseThaiTaxID("1101700203115")
+		assert.Nil(t, err)
+		assert.False(t, id.IsJuristic())
+		assert.Equal(t, "", id.OfficeCode())
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		id, err := validator.ParseThaiJuristicID("1101700203115")
+		assert.EqualError(t, err, "must be the tax ID of a juristic person")
+		assert.Equal(t, "", id.String())
+		assert.False(t, id.IsJuristic())
+		assert.Equal(t, validator.EntityCategory(""), id.Category())
+	})
+
+	t.Run("Unhappy, zero value", func(t *testing.T) {
+		var id validator.ThaiTaxID
+		assert.Equal(t, validator.EntityCategory(""), id.Category())
+		assert.False(t, id.IsJuristic())
+		assert.Equal(t, "", id.OfficeCode())
+		assert.Equal(t, "", id.Digits())
+		assert.Equal(t, "", id.String())
+
+		id, _ = validator.ParseThaiTaxID("invalid")
+		assert.Equal(t, validator.EntityCategory(""), id.Category())
+	})
+}
+
+func TestStruct_ThaiTaxID(t *testing.T) {
+	type merchant struct {
+		TaxID      string `json:"tax_id" validate:"required,thaitaxid"`
+		JuristicID string `json:"juristic_id" validate:"thaijuristicid"`
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		assert.Nil(t, validator.Struct(merchant{TaxID: "1101700203115", JuristicID: "0107537000009"}))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		assert.EqualError(t, validator.Struct(merchant{TaxID: "0107537000008", JuristicID: "1101700203115"}),
+			"tax_id must be a Thai tax ID with a valid check digit; juristic_id must be the tax ID of a juristic person")
+	})
+}
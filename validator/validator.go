@@ -16,65 +16,116 @@ import (
 )
 
 func IsValidEmail(email string) bool {
-	return regexp.MustCompile(`^[\w-\.]+@([\w-]+\.)+[\w-]{2,4}$`).
-		MatchString(email)
+	return ValidateEmail(email) == nil
 }
 
+func ValidateEmail(email string) error {
+	if !regexp.MustCompile(`^[\w-\.]+@([\w-]+\.)+[\w-]{2,4}$`).MatchString(email) {
+		return newValidationError(CodeInvalidEmail, "email", "must be a valid email address", nil)
+	}
+	return nil
+}
+
+// IsValidPhoneNumber only checks the characters of a phone number, use
+// ValidatePhoneNumberForRegion or a PhonePolicy to check it is a real one.
 func IsValidPhoneNumber(phoneNumber string) bool {
-	return regexp.MustCompile(`^[+]{0,1}[(]{0,1}[0-9]{1,4}[)]{0,1}[-\s\./0-9]*$`).
-		MatchString(phoneNumber)
+	return ValidatePhoneNumber(phoneNumber) == nil
+}
+
+func ValidatePhoneNumber(phoneNumber string) error {
+	if !regexp.MustCompile(`^[+]{0,1}[(]{0,1}[0-9]{1,4}[)]{0,1}[-\s\./0-9]*$`).MatchString(phoneNumber) {
+		return newValidationError(CodeInvalidPhoneNumber, "phone", "must be a valid phone number", nil)
+	}
+	return nil
 }
 
 func IsValidUUID(s string) bool {
-	_, err := uuid.Parse(s)
-	return err == nil
+	return ValidateUUID(s) == nil
+}
+
+func ValidateUUID(s string) error {
+	if _, err := uuid.Parse(s); err != nil {
+		return newValidationError(CodeInvalidUUID, "uuid", "must be a valid UUID", nil)
+	}
+	return nil
 }
 
 func IsValidSlug(slug string) bool {
-	return regexp.
+	return ValidateSlug(slug) == nil
+}
+
+func ValidateSlug(slug string) error {
+	isValid := regexp.
 		MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`). // lowercase kebab case string
 		MatchString(slug)
+	if !isValid {
+		return newValidationError(CodeInvalidSlug, "slug", "must be lowercase words separated by hyphens", nil)
+	}
+	return nil
 }
 
 func IsValidCurrency(currency string) bool {
+	return ValidateCurrency(currency) == nil
+}
+
+func ValidateCurrency(currency string) error {
+	invalid := newValidationError(CodeInvalidCurrency, "currency", "must be an ISO 4217 currency code", nil)
 	if _, err := _currency.ParseISO(currency); err != nil {
-		return false
+		return invalid
 	}
-	return regexp.
+	isValid := regexp.
 		MustCompile(`^[A-Z]+(?:-[A-Z]+)*$`).
 		MatchString(currency) && len(currency) == 3
+	if !isValid {
+		return invalid
+	}
+	return nil
 }
 
 func IsValidCountry(country string) bool {
+	return ValidateCountry(country) == nil
+}
+
+func ValidateCountry(country string) error {
+	invalid := newValidationError(CodeInvalidCountry, "country", "must be an ISO 3166 alpha-3 country code", nil)
 	ok := regexp.
 		MustCompile(`^[A-Z]+(?:-[A-Z]+)*$`).
 		MatchString(country) && len(country) == 3
 	if !ok {
-		return false
+		return invalid
 	}
 
 	_, err := gountries.New().FindCountryByAlpha(country)
 	if err != nil {
-		return false
+		return invalid
 	}
-	return true
+	return nil
 }
 
 func IsValidNumericFromString(decimalOfString string) bool {
+	return ValidateNumericFromString(decimalOfString) == nil
+}
+
+func ValidateNumericFromString(decimalOfString string) error {
 	_, err := decimal.NewFromString(decimalOfString)
 	if err != nil {
-		return false
+		return newValidationError(CodeInvalidNumeric, "numeric", "must be a number", nil)
 	}
-	return true
+	return nil
 }
 
 func ValidatePasswordComplexity(password string) bool {
+	return ValidatePassword(password) == nil
+}
+
+// ValidatePassword reports the first character class missing from password.
+func ValidatePassword(password string) error {
 	// Must have at least one lower case
 	isValid := regexp.
 		MustCompile(`[a-z]`).
 		MatchString(password)
 	if !isValid {
-		return false
+		return newValidationError(CodePasswordMissingLowercase, "password", "must contain a lowercase letter", nil)
 	}
 
 	// Must have at least one upper case
@@ -82,7 +133,7 @@ func ValidatePasswordComplexity(password string) bool {
 		MustCompile(`[A-Z]`).
 		MatchString(password)
 	if !isValid {
-		return false
+		return newValidationError(CodePasswordMissingUppercase, "password", "must contain an uppercase letter", nil)
 	}
 
 	// Must have at least one number
@@ -90,57 +141,82 @@ func ValidatePasswordComplexity(password string) bool {
 		MustCompile(`\d`).
 		MatchString(password)
 	if !isValid {
-		return false
+		return newValidationError(CodePasswordMissingNumber, "password", "must contain a number", nil)
 	}
 
 	// Must have at least one symbol
+	symbols := "-+_!@#$%^&*.,?"
 	isValid = regexp.
 		MustCompile(`[-+_!@#$%^&*.,?]`).
 		MatchString(password)
 	if !isValid {
-		return false
+		return newValidationError(CodePasswordMissingSymbol, "password", "must contain one of the symbols "+symbols,
+			map[string]interface{}{"symbols": symbols})
 	}
 
-	return true
+	return nil
 }
 
 func IsValidBoolFromString(boolOfString string) bool {
+	return ValidateBoolFromString(boolOfString) == nil
+}
+
+func ValidateBoolFromString(boolOfString string) error {
 	_, err := strconv.ParseBool(boolOfString)
 	if err != nil {
-		return false
+		return newValidationError(CodeInvalidBool, "bool", "must be a boolean", nil)
 	}
-	return true
+	return nil
 }
 
 func IsValidDateTimeFromString(layoutOfDateTime string, dateTimeOfString string) bool {
+	return ValidateDateTimeFromString(layoutOfDateTime, dateTimeOfString) == nil
+}
+
+func ValidateDateTimeFromString(layoutOfDateTime string, dateTimeOfString string) error {
 	_, err := time.Parse(layoutOfDateTime, dateTimeOfString)
 	if err != nil {
-		return false
+		return newValidationError(CodeInvalidDateTime, "datetime", "must be a date time formatted as "+layoutOfDateTime,
+			map[string]interface{}{"layout": layoutOfDateTime})
 	}
-	return true
+	return nil
 }
 
 func IsWeakPin6Digit(pin string) bool {
+	return CodeOf(ValidatePin6Digit(pin)) == CodeWeakPin
+}
+
+// ValidatePin6Digit rejects a pin that is not 6 digits or is easy to guess.
+func ValidatePin6Digit(pin string) error {
+	if !regexp.MustCompile(`^\d{6}$`).MatchString(pin) {
+		return newValidationError(CodeInvalidPin, "pin", "must be 6 digits", map[string]interface{}{"length": 6})
+	}
+
 	arrWeakPin := utils.GetWeakPin6Digit()
 	for _, code := range arrWeakPin {
 		if pin == code {
-			return true
+			return newValidationError(CodeWeakPin, "pin", "must not be an easy to guess pin", nil)
 		}
 	}
-	return false
+	return nil
 }
 
 func IsValidUsername(username string) bool {
+	return ValidateUsername(username) == nil
+}
+
+func ValidateUsername(username string) error {
 	// Must more than 6 digit
 	if len(username) < 6 {
-		return false
+		return newValidationError(CodeUsernameTooShort, "username", "must be at least 6 characters",
+			map[string]interface{}{"min": 6})
 	}
 
 	isValid := regexp.
 		MustCompile(`^[a-z0-9]+(?:[a-z0-9]+)*$`).
 		MatchString(username)
 	if !isValid {
-		return false
+		return newValidationError(CodeInvalidUsername, "username", "must contain only lowercase letters and numbers", nil)
 	}
 
 	// check duplicate characters in string
@@ -148,50 +224,78 @@ func IsValidUsername(username string) bool {
 	for _, text := range runes {
 		textCheck := string(text) + string(text) + string(text) + string(text) + string(text)
 		if strings.Count(username, textCheck) >= 1 {
-			return false
+			return newValidationError(CodeUsernameRepeatedCharacter, "username",
+				"must not repeat a character 5 times in a row", map[string]interface{}{"max": 4})
 		}
 	}
 
-	return true
+	return nil
 }
 
 func IsJSON(str string) bool {
+	return ValidateJSON(str) == nil
+}
+
+// ValidateJSON accepts a JSON object or array.
+func ValidateJSON(str string) error {
+	invalid := newValidationError(CodeInvalidJSON, "json", "must be a JSON object or array", nil)
 	var js interface{}
 	if err := json.Unmarshal([]byte(str), &js); err != nil {
-		return false
+		return invalid
 	}
 	switch js.(type) {
 	case map[string]interface{}:
-		return true
+		return nil
 	case []interface{}:
-		return true
+		return nil
 	default:
-		return false
+		return invalid
 	}
 }
 
 func IsBase64(str string) bool {
-	return constants.RxBase64.MatchString(str)
+	return ValidateBase64(str) == nil
+}
+
+func ValidateBase64(str string) error {
+	if !constants.RxBase64.MatchString(str) {
+		return newValidationError(CodeInvalidBase64, "base64", "must be base64 encoded", nil)
+	}
+	return nil
 }
 
 func IsBase64DataType(str string) bool {
-	return constants.RxBase64DataType.MatchString(str)
+	return ValidateBase64DataType(str) == nil
+}
+
+func ValidateBase64DataType(str string) error {
+	if !constants.RxBase64DataType.MatchString(str) {
+		return newValidationError(CodeInvalidBase64DataType, "base64datatype", "must be a base64 data URI", nil)
+	}
+	return nil
 }
 
 func IsURL(str string) bool {
+	return ValidateURL(str) == nil
+}
+
+func ValidateURL(str string) error {
+	invalid := newValidationError(CodeInvalidURL, "url", "must be a valid URL", nil)
 	if str == "" || len(str) >= 2083 || len(str) <= 3 || strings.HasPrefix(str, ".") {
-		return false
+		return invalid
 	}
 	u, err := url.Parse(str)
 	if err != nil {
-		return false
+		return invalid
 	}
 	if strings.HasPrefix(u.Host, ".") {
-		return false
+		return invalid
 	}
 	if u.Host == "" && (u.Path != "" && !strings.Contains(u.Path, ".")) {
-		return false
+		return invalid
 	}
-	return constants.RxURL.MatchString(str)
-
+	if !constants.RxURL.MatchString(str) {
+		return invalid
+	}
+	return nil
 }
@@ -390,3 +390,69 @@ func TestIsValidUsername(t *testing.T) {
 		assert.False(t, validator.IsURL(`httpx://dictionary.cambridge.org/dictionary/english/mock`))
 	})
 }
+
+func TestValidate(t *testing.T) {
+	t.Run("Happy", func(t *testing.T) {
+		assert.Nil(t, validator.ValidateEmail("example@gmail.com"))
+		assert.Nil(t, validator.ValidatePhoneNumber("089-1234567"))
+		assert.Nil(t, validator.ValidateUUID("fbd3036f-0f1c-4e98-b71c-d4cd61213f90"))
+		assert.Nil(t, validator.ValidateSlug("ndigital-test"))
+		assert.Nil(t, validator.ValidateCurrency("THB"))
+		assert.Nil(t, validator.ValidateCountry("THA"))
+		assert.Nil(t, validator.ValidateNumericFromString("-1.00"))
+		assert.Nil(t, validator.ValidatePassword("Passw0rd!"))
+		assert.Nil(t, validator.ValidateBoolFromString("true"))
+		assert.Nil(t, validator.ValidateDateTimeFromString("2006-01-02", "2020-05-01"))
+		assert.Nil(t, validator.ValidatePin6Digit("394816"))
+		assert.Nil(t, validator.ValidateUsername("username1"))
+		assert.Nil(t, validator.ValidateJSON(`{"key": "value"}`))
+		assert.Nil(t, validator.ValidateBase64("dGVzdA=="))
+		assert.Nil(t, validator.ValidateBase64DataType("data:image/png;base64,dGVzdA=="))
+		assert.Nil(t, validator.ValidateURL("https://example.com/path"))
+	})
+
+	t.Run("Unhappy", func(t *testing.T) {
+		cases := []struct {
+			err  error
+			code validator.Code
+			rule string
+		}{
+			{validator.ValidateEmail("example@"), validator.CodeInvalidEmail, "email"},
+			{validator.ValidatePhoneNumber("++09334454433"), validator.CodeInvalidPhoneNumber, "phone"},
+			{validator.ValidateUUID("cccc-ccc-ccc-ccc-ccccc"), validator.CodeInvalidUUID, "uuid"},
+			{validator.ValidateSlug("Ndigital-test"), validator.CodeInvalidSlug, "slug"},
+			{validator.ValidateCurrency("usd"), validator.CodeInvalidCurrency, "currency"},
+			{validator.ValidateCountry("THB"), validator.CodeInvalidCountry, "country"},
+			{validator.ValidateNumericFromString("1. 0"), validator.CodeInvalidNumeric, "numeric"},
+			{validator.ValidatePassword("PASSW0RD!"), validator.CodePasswordMissingLowercase, "password"},
+			{validator.ValidatePassword("passw0rd!"), validator.CodePasswordMissingUppercase, "password"},
+			{validator.ValidatePassword("Password!"), validator.CodePasswordMissingNumber, "password"},
+			{validator.ValidatePassword("Passw0rd"), validator.CodePasswordMissingSymbol, "password"},
+			{validator.ValidateBoolFromString("yes"), validator.CodeInvalidBool, "bool"},
+			{validator.ValidateDateTimeFromString("2006-01-02", "01/05/2020"), validator.CodeInvalidDateTime, "datetime"},
+			{validator.ValidatePin6Digit("12345a"), validator.CodeInvalidPin, "pin"},
+			{validator.ValidatePin6Digit("123456"), validator.CodeWeakPin, "pin"},
+			{validator.ValidateUsername("user"), validator.CodeUsernameTooShort, "username"},
+			{validator.ValidateUsername("User_name"), validator.CodeInvalidUsername, "username"},
+			{validator.ValidateUsername("useraaaaa"), validator.CodeUsernameRepeatedCharacter, "username"},
+			{validator.ValidateJSON(`"string"`), validator.CodeInvalidJSON, "json"},
+			{validator.ValidateBase64("dGVzdA="), validator.CodeInvalidBase64, "base64"},
+			{validator.ValidateBase64DataType("dGVzdA=="), validator.CodeInvalidBase64DataType, "base64datatype"},
+			{validator.ValidateURL("abc"), validator.CodeInvalidURL, "url"},
+		}
+		for _, c := range cases {
+			validationError, ok := c.err.(*validator.ValidationError)
+			if assert.True(t, ok, "%v should be a ValidationError", c.err) {
+				assert.Equal(t, c.code, validationError.Code)
+				assert.Equal(t, c.rule, validationError.Rule)
+				assert.NotEmpty(t, validationError.Message)
+			}
+		}
+	})
+
+	t.Run("Unhappy, params", func(t *testing.T) {
+		err := validator.ValidateDateTimeFromString("2006-01-02", "01/05/2020")
+		assert.EqualError(t, err, "must be a date time formatted as 2006-01-02")
+		assert.Equal(t, map[string]interface{}{"layout": "2006-01-02"}, err.(*validator.ValidationError).Params)
+	})
+}